@@ -0,0 +1,591 @@
+package main
+
+//go:generate go run gendex.go
+
+// dexDetails holds the Pokédex details of species by National Dex number.
+// gendex.go rewrites this file from PokeAPI with every species; until it
+// is run, only the entries below are known and the other species show
+// number, generation and types alone. Evolution lines are complete.
+var dexDetails = map[int]DexEntry{
+	// ─── Gen I (#1–151) ───────────────────────────────────────
+	1:   {Genus: "Seed Pokémon", Height: 0.7, Weight: 6.9, Abilities: []string{"Overgrow", "Chlorophyll"}, Stats: [6]int{45, 49, 49, 65, 65, 45}},
+	2:   {Genus: "Seed Pokémon", Height: 1.0, Weight: 13.0, Abilities: []string{"Overgrow", "Chlorophyll"}, Stats: [6]int{60, 62, 63, 80, 80, 60}},
+	3:   {Genus: "Seed Pokémon", Height: 2.0, Weight: 100.0, Abilities: []string{"Overgrow", "Chlorophyll"}, Stats: [6]int{80, 82, 83, 100, 100, 80}},
+	4:   {Genus: "Lizard Pokémon", Height: 0.6, Weight: 8.5, Abilities: []string{"Blaze", "Solar Power"}, Stats: [6]int{39, 52, 43, 60, 50, 65}},
+	5:   {Genus: "Flame Pokémon", Height: 1.1, Weight: 19.0, Abilities: []string{"Blaze", "Solar Power"}, Stats: [6]int{58, 64, 58, 80, 65, 80}},
+	6:   {Genus: "Flame Pokémon", Height: 1.7, Weight: 90.5, Abilities: []string{"Blaze", "Solar Power"}, Stats: [6]int{78, 84, 78, 109, 85, 100}},
+	7:   {Genus: "Tiny Turtle Pokémon", Height: 0.5, Weight: 9.0, Abilities: []string{"Torrent", "Rain Dish"}, Stats: [6]int{44, 48, 65, 50, 64, 43}},
+	8:   {Genus: "Turtle Pokémon", Height: 1.0, Weight: 22.5, Abilities: []string{"Torrent", "Rain Dish"}, Stats: [6]int{59, 63, 80, 65, 80, 58}},
+	9:   {Genus: "Shellfish Pokémon", Height: 1.6, Weight: 85.5, Abilities: []string{"Torrent", "Rain Dish"}, Stats: [6]int{79, 83, 100, 85, 105, 78}},
+	25:  {Genus: "Mouse Pokémon", Height: 0.4, Weight: 6.0, Abilities: []string{"Static", "Lightning Rod"}, Stats: [6]int{35, 55, 40, 50, 50, 90}},
+	26:  {Genus: "Mouse Pokémon", Height: 0.8, Weight: 30.0, Abilities: []string{"Static", "Lightning Rod"}, Stats: [6]int{60, 90, 55, 90, 80, 110}},
+	39:  {Genus: "Balloon Pokémon", Height: 0.5, Weight: 5.5, Abilities: []string{"Cute Charm", "Competitive", "Friend Guard"}, Stats: [6]int{115, 45, 20, 45, 25, 20}},
+	52:  {Genus: "Scratch Cat Pokémon", Height: 0.4, Weight: 4.2, Abilities: []string{"Pickup", "Technician", "Unnerve"}, Stats: [6]int{40, 45, 35, 40, 40, 90}},
+	54:  {Genus: "Duck Pokémon", Height: 0.8, Weight: 19.6, Abilities: []string{"Damp", "Cloud Nine", "Swift Swim"}, Stats: [6]int{50, 52, 48, 65, 50, 55}},
+	94:  {Genus: "Shadow Pokémon", Height: 1.5, Weight: 40.5, Abilities: []string{"Cursed Body"}, Stats: [6]int{60, 65, 60, 130, 75, 110}},
+	129: {Genus: "Fish Pokémon", Height: 0.9, Weight: 10.0, Abilities: []string{"Swift Swim", "Rattled"}, Stats: [6]int{20, 10, 55, 15, 20, 80}},
+	130: {Genus: "Atrocious Pokémon", Height: 6.5, Weight: 235.0, Abilities: []string{"Intimidate", "Moxie"}, Stats: [6]int{95, 125, 79, 60, 100, 81}},
+	133: {Genus: "Evolution Pokémon", Height: 0.3, Weight: 6.5, Abilities: []string{"Run Away", "Adaptability", "Anticipation"}, Stats: [6]int{55, 55, 50, 45, 65, 55}},
+	134: {Genus: "Bubble Jet Pokémon", Height: 1.0, Weight: 29.0, Abilities: []string{"Water Absorb", "Hydration"}, Stats: [6]int{130, 65, 60, 110, 95, 65}},
+	135: {Genus: "Lightning Pokémon", Height: 0.8, Weight: 24.5, Abilities: []string{"Volt Absorb", "Quick Feet"}, Stats: [6]int{65, 65, 60, 110, 95, 130}},
+	136: {Genus: "Flame Pokémon", Height: 0.9, Weight: 25.0, Abilities: []string{"Flash Fire", "Guts"}, Stats: [6]int{65, 130, 60, 95, 110, 65}},
+	143: {Genus: "Sleeping Pokémon", Height: 2.1, Weight: 460.0, Abilities: []string{"Immunity", "Thick Fat", "Gluttony"}, Stats: [6]int{160, 110, 65, 65, 110, 30}},
+	149: {Genus: "Dragon Pokémon", Height: 2.2, Weight: 210.0, Abilities: []string{"Inner Focus", "Multiscale"}, Stats: [6]int{91, 134, 95, 100, 100, 80}},
+	150: {Genus: "Genetic Pokémon", Height: 2.0, Weight: 122.0, Abilities: []string{"Pressure", "Unnerve"}, Stats: [6]int{106, 110, 90, 154, 90, 130}},
+	151: {Genus: "New Species Pokémon", Height: 0.4, Weight: 4.0, Abilities: []string{"Synchronize"}, Stats: [6]int{100, 100, 100, 100, 100, 100}},
+
+	// ─── Gen II (#152–251) ────────────────────────────────────
+	196: {Genus: "Sun Pokémon", Height: 0.9, Weight: 26.5, Abilities: []string{"Synchronize", "Magic Bounce"}, Stats: [6]int{65, 65, 60, 130, 95, 110}},
+	197: {Genus: "Moonlight Pokémon", Height: 1.0, Weight: 27.0, Abilities: []string{"Synchronize", "Inner Focus"}, Stats: [6]int{95, 65, 110, 60, 130, 65}},
+	248: {Genus: "Armor Pokémon", Height: 2.0, Weight: 202.0, Abilities: []string{"Sand Stream", "Unnerve"}, Stats: [6]int{100, 134, 110, 95, 100, 61}},
+
+	// ─── Gen III (#252–386) ───────────────────────────────────
+	282: {Genus: "Embrace Pokémon", Height: 1.6, Weight: 48.4, Abilities: []string{"Synchronize", "Trace", "Telepathy"}, Stats: [6]int{68, 65, 65, 125, 115, 80}},
+
+	// ─── Gen IV (#387–493) ────────────────────────────────────
+	445: {Genus: "Mach Pokémon", Height: 1.9, Weight: 95.0, Abilities: []string{"Sand Veil", "Rough Skin"}, Stats: [6]int{108, 130, 95, 80, 85, 102}},
+	448: {Genus: "Aura Pokémon", Height: 1.2, Weight: 54.0, Abilities: []string{"Steadfast", "Inner Focus", "Justified"}, Stats: [6]int{70, 110, 70, 115, 70, 90}},
+	470: {Genus: "Verdant Pokémon", Height: 1.0, Weight: 25.5, Abilities: []string{"Leaf Guard", "Chlorophyll"}, Stats: [6]int{65, 110, 130, 60, 65, 95}},
+	471: {Genus: "Fresh Snow Pokémon", Height: 0.8, Weight: 25.9, Abilities: []string{"Snow Cloak", "Ice Body"}, Stats: [6]int{65, 60, 110, 130, 95, 65}},
+
+	// ─── Gen VI (#650–721) ────────────────────────────────────
+	700: {Genus: "Intertwining Pokémon", Height: 1.0, Weight: 23.5, Abilities: []string{"Cute Charm", "Pixilate"}, Stats: [6]int{95, 65, 65, 110, 130, 60}},
+
+	// ─── Gen IX (#906–1025) ───────────────────────────────────
+	999:  {Genus: "Coin Chest Pokémon", Height: 0.3, Weight: 5.0, Abilities: []string{"Rattled"}, Stats: [6]int{45, 30, 70, 75, 70, 10}},
+	1000: {Genus: "Coin Entity Pokémon", Height: 1.2, Weight: 30.0, Abilities: []string{"Good as Gold"}, Stats: [6]int{87, 60, 95, 133, 91, 84}},
+}
+
+// evolutions maps a species to the species it evolves into, by National
+// Dex number.
+var evolutions = map[int][]int{
+	// ─── Gen I (#1–151) ───────────────────────────────────────
+	1:   {2},
+	2:   {3},
+	4:   {5},
+	5:   {6},
+	7:   {8},
+	8:   {9},
+	10:  {11},
+	11:  {12},
+	13:  {14},
+	14:  {15},
+	16:  {17},
+	17:  {18},
+	19:  {20},
+	21:  {22},
+	23:  {24},
+	25:  {26},
+	27:  {28},
+	29:  {30},
+	30:  {31},
+	32:  {33},
+	33:  {34},
+	35:  {36},
+	37:  {38},
+	39:  {40},
+	41:  {42},
+	42:  {169},
+	43:  {44},
+	44:  {45, 182},
+	46:  {47},
+	48:  {49},
+	50:  {51},
+	52:  {53, 863},
+	54:  {55},
+	56:  {57},
+	57:  {979},
+	58:  {59},
+	60:  {61},
+	61:  {62, 186},
+	63:  {64},
+	64:  {65},
+	66:  {67},
+	67:  {68},
+	69:  {70},
+	70:  {71},
+	72:  {73},
+	74:  {75},
+	75:  {76},
+	77:  {78},
+	79:  {80, 199},
+	81:  {82},
+	82:  {462},
+	83:  {865},
+	84:  {85},
+	86:  {87},
+	88:  {89},
+	90:  {91},
+	92:  {93},
+	93:  {94},
+	95:  {208},
+	96:  {97},
+	98:  {99},
+	100: {101},
+	102: {103},
+	104: {105},
+	108: {463},
+	109: {110},
+	111: {112},
+	112: {464},
+	113: {242},
+	114: {465},
+	116: {117},
+	117: {230},
+	118: {119},
+	120: {121},
+	122: {866},
+	123: {212, 899},
+	125: {466},
+	126: {467},
+	129: {130},
+	133: {134, 135, 136, 196, 197, 470, 471, 700},
+	137: {233},
+	138: {139},
+	140: {141},
+	147: {148},
+	148: {149},
+
+	// ─── Gen II (#152–251) ────────────────────────────────────
+	152: {153},
+	153: {154},
+	155: {156},
+	156: {157},
+	158: {159},
+	159: {160},
+	161: {162},
+	163: {164},
+	165: {166},
+	167: {168},
+	170: {171},
+	172: {25},
+	173: {35},
+	174: {39},
+	175: {176},
+	176: {468},
+	177: {178},
+	179: {180},
+	180: {181},
+	183: {184},
+	187: {188},
+	188: {189},
+	190: {424},
+	191: {192},
+	193: {469},
+	194: {195, 980},
+	198: {430},
+	200: {429},
+	203: {981},
+	204: {205},
+	206: {982},
+	207: {472},
+	209: {210},
+	211: {903},
+	215: {461, 902},
+	216: {217},
+	217: {905},
+	218: {219},
+	220: {221},
+	221: {473},
+	222: {864},
+	223: {224},
+	228: {229},
+	231: {232},
+	233: {474},
+	234: {900},
+	236: {106, 107, 237},
+	238: {124},
+	239: {125},
+	240: {126},
+	246: {247},
+	247: {248},
+
+	// ─── Gen III (#252–386) ───────────────────────────────────
+	252: {253},
+	253: {254},
+	255: {256},
+	256: {257},
+	258: {259},
+	259: {260},
+	261: {262},
+	263: {264},
+	264: {862},
+	265: {266, 268},
+	266: {267},
+	268: {269},
+	270: {271},
+	271: {272},
+	273: {274},
+	274: {275},
+	276: {277},
+	278: {279},
+	280: {281},
+	281: {282, 475},
+	283: {284},
+	285: {286},
+	287: {288},
+	288: {289},
+	290: {291, 292},
+	293: {294},
+	294: {295},
+	296: {297},
+	298: {183},
+	299: {476},
+	300: {301},
+	304: {305},
+	305: {306},
+	307: {308},
+	309: {310},
+	315: {407},
+	316: {317},
+	318: {319},
+	320: {321},
+	322: {323},
+	325: {326},
+	328: {329},
+	329: {330},
+	331: {332},
+	333: {334},
+	339: {340},
+	341: {342},
+	343: {344},
+	345: {346},
+	347: {348},
+	349: {350},
+	353: {354},
+	355: {356},
+	356: {477},
+	360: {202},
+	361: {362, 478},
+	363: {364},
+	364: {365},
+	366: {367, 368},
+	371: {372},
+	372: {373},
+	374: {375},
+	375: {376},
+
+	// ─── Gen IV (#387–493) ────────────────────────────────────
+	387: {388},
+	388: {389},
+	390: {391},
+	391: {392},
+	393: {394},
+	394: {395},
+	396: {397},
+	397: {398},
+	399: {400},
+	401: {402},
+	403: {404},
+	404: {405},
+	406: {315},
+	408: {409},
+	410: {411},
+	412: {413, 414},
+	415: {416},
+	418: {419},
+	420: {421},
+	422: {423},
+	425: {426},
+	427: {428},
+	431: {432},
+	433: {358},
+	434: {435},
+	436: {437},
+	438: {185},
+	439: {122},
+	440: {113},
+	443: {444},
+	444: {445},
+	446: {143},
+	447: {448},
+	449: {450},
+	451: {452},
+	453: {454},
+	456: {457},
+	458: {226},
+	459: {460},
+	489: {490},
+
+	// ─── Gen V (#494–649) ─────────────────────────────────────
+	495: {496},
+	496: {497},
+	498: {499},
+	499: {500},
+	501: {502},
+	502: {503},
+	504: {505},
+	506: {507},
+	507: {508},
+	509: {510},
+	511: {512},
+	513: {514},
+	515: {516},
+	517: {518},
+	519: {520},
+	520: {521},
+	522: {523},
+	524: {525},
+	525: {526},
+	527: {528},
+	529: {530},
+	532: {533},
+	533: {534},
+	535: {536},
+	536: {537},
+	540: {541},
+	541: {542},
+	543: {544},
+	544: {545},
+	546: {547},
+	548: {549},
+	550: {901},
+	551: {552},
+	552: {553},
+	554: {555},
+	557: {558},
+	559: {560},
+	562: {563, 867},
+	564: {565},
+	566: {567},
+	568: {569},
+	570: {571},
+	572: {573},
+	574: {575},
+	575: {576},
+	577: {578},
+	578: {579},
+	580: {581},
+	582: {583},
+	583: {584},
+	585: {586},
+	588: {589},
+	590: {591},
+	592: {593},
+	595: {596},
+	597: {598},
+	599: {600},
+	600: {601},
+	602: {603},
+	603: {604},
+	605: {606},
+	607: {608},
+	608: {609},
+	610: {611},
+	611: {612},
+	613: {614},
+	616: {617},
+	619: {620},
+	622: {623},
+	624: {625},
+	625: {983},
+	627: {628},
+	629: {630},
+	633: {634},
+	634: {635},
+	636: {637},
+
+	// ─── Gen VI (#650–721) ────────────────────────────────────
+	650: {651},
+	651: {652},
+	653: {654},
+	654: {655},
+	656: {657},
+	657: {658},
+	659: {660},
+	661: {662},
+	662: {663},
+	664: {665},
+	665: {666},
+	667: {668},
+	669: {670},
+	670: {671},
+	672: {673},
+	674: {675},
+	677: {678},
+	679: {680},
+	680: {681},
+	682: {683},
+	684: {685},
+	686: {687},
+	688: {689},
+	690: {691},
+	692: {693},
+	694: {695},
+	696: {697},
+	698: {699},
+	704: {705},
+	705: {706},
+	708: {709},
+	710: {711},
+	712: {713},
+	714: {715},
+
+	// ─── Gen VII (#722–809) ───────────────────────────────────
+	722: {723},
+	723: {724},
+	725: {726},
+	726: {727},
+	728: {729},
+	729: {730},
+	731: {732},
+	732: {733},
+	734: {735},
+	736: {737},
+	737: {738},
+	739: {740},
+	742: {743},
+	744: {745},
+	747: {748},
+	749: {750},
+	751: {752},
+	753: {754},
+	755: {756},
+	757: {758},
+	759: {760},
+	761: {762},
+	762: {763},
+	767: {768},
+	769: {770},
+	772: {773},
+	782: {783},
+	783: {784},
+	789: {790},
+	790: {791, 792},
+	803: {804},
+	808: {809},
+
+	// ─── Gen VIII (#810–905) ──────────────────────────────────
+	810: {811},
+	811: {812},
+	813: {814},
+	814: {815},
+	816: {817},
+	817: {818},
+	819: {820},
+	821: {822},
+	822: {823},
+	824: {825},
+	825: {826},
+	827: {828},
+	829: {830},
+	831: {832},
+	833: {834},
+	835: {836},
+	837: {838},
+	838: {839},
+	840: {841, 842, 1011},
+	843: {844},
+	846: {847},
+	848: {849},
+	850: {851},
+	852: {853},
+	854: {855},
+	856: {857},
+	857: {858},
+	859: {860},
+	860: {861},
+	868: {869},
+	872: {873},
+	878: {879},
+	884: {1018},
+	885: {886},
+	886: {887},
+	891: {892},
+
+	// ─── Gen IX (#906–1025) ───────────────────────────────────
+	906:  {907},
+	907:  {908},
+	909:  {910},
+	910:  {911},
+	912:  {913},
+	913:  {914},
+	915:  {916},
+	917:  {918},
+	919:  {920},
+	921:  {922},
+	922:  {923},
+	924:  {925},
+	926:  {927},
+	928:  {929},
+	929:  {930},
+	932:  {933},
+	933:  {934},
+	935:  {936, 937},
+	938:  {939},
+	940:  {941},
+	942:  {943},
+	944:  {945},
+	946:  {947},
+	948:  {949},
+	951:  {952},
+	953:  {954},
+	955:  {956},
+	957:  {958},
+	958:  {959},
+	960:  {961},
+	963:  {964},
+	965:  {966},
+	969:  {970},
+	971:  {972},
+	974:  {975},
+	996:  {997},
+	997:  {998},
+	999:  {1000},
+	1011: {1019},
+	1012: {1013},
+}
+
+// speciesNames holds the names of species in other languages by National
+// Dex number and language, leaving out those spelled as in English.
+var speciesNames = map[int]map[string]string{
+	// ─── Gen I (#1–151) ───────────────────────────────────────
+	1:   {"ja": "フシギダネ", "de": "Bisasam", "fr": "Bulbizarre", "ko": "이상해씨", "zh": "妙蛙种子"},
+	2:   {"ja": "フシギソウ", "de": "Bisaknosp", "fr": "Herbizarre", "ko": "이상해풀", "zh": "妙蛙草"},
+	3:   {"ja": "フシギバナ", "de": "Bisaflor", "fr": "Florizarre", "ko": "이상해꽃", "zh": "妙蛙花"},
+	4:   {"ja": "ヒトカゲ", "de": "Glumanda", "fr": "Salamèche", "ko": "파이리", "zh": "小火龙"},
+	5:   {"ja": "リザード", "de": "Glutexo", "fr": "Reptincel", "ko": "리자드", "zh": "火恐龙"},
+	6:   {"ja": "リザードン", "de": "Glurak", "fr": "Dracaufeu", "ko": "리자몽", "zh": "喷火龙"},
+	7:   {"ja": "ゼニガメ", "de": "Schiggy", "fr": "Carapuce", "ko": "꼬부기", "zh": "杰尼龟"},
+	8:   {"ja": "カメール", "de": "Schillok", "fr": "Carabaffe", "ko": "어니부기", "zh": "卡咪龟"},
+	9:   {"ja": "カメックス", "de": "Turtok", "fr": "Tortank", "ko": "거북왕", "zh": "水箭龟"},
+	25:  {"ja": "ピカチュウ", "ko": "피카츄", "zh": "皮卡丘"},
+	26:  {"ja": "ライチュウ", "ko": "라이츄", "zh": "雷丘"},
+	39:  {"ja": "プリン", "de": "Pummeluff", "fr": "Rondoudou", "ko": "푸린", "zh": "胖丁"},
+	52:  {"ja": "ニャース", "de": "Mauzi", "fr": "Miaouss", "ko": "나옹", "zh": "喵喵"},
+	54:  {"ja": "コダック", "de": "Enton", "fr": "Psykokwak", "ko": "고라파덕", "zh": "可达鸭"},
+	94:  {"ja": "ゲンガー", "fr": "Ectoplasma", "ko": "팬텀", "zh": "耿鬼"},
+	129: {"ja": "コイキング", "de": "Karpador", "fr": "Magicarpe", "ko": "잉어킹", "zh": "鲤鱼王"},
+	130: {"ja": "ギャラドス", "de": "Garados", "fr": "Léviator", "ko": "갸라도스", "zh": "暴鲤龙"},
+	133: {"ja": "イーブイ", "de": "Evoli", "fr": "Évoli", "ko": "이브이", "zh": "伊布"},
+	134: {"ja": "シャワーズ", "de": "Aquana", "fr": "Aquali", "ko": "샤미드", "zh": "水伊布"},
+	135: {"ja": "サンダース", "de": "Blitza", "fr": "Voltali", "ko": "쥬피썬더", "zh": "雷伊布"},
+	136: {"ja": "ブースター", "de": "Flamara", "fr": "Pyroli", "ko": "부스터", "zh": "火伊布"},
+	143: {"ja": "カビゴン", "de": "Relaxo", "fr": "Ronflex", "ko": "잠만보", "zh": "卡比兽"},
+	149: {"ja": "カイリュー", "de": "Dragoran", "fr": "Dracolosse", "ko": "망나뇽", "zh": "快龙"},
+	150: {"ja": "ミュウツー", "de": "Mewtu", "ko": "뮤츠", "zh": "超梦"},
+	151: {"ja": "ミュウ", "ko": "뮤", "zh": "梦幻"},
+
+	// ─── Gen II (#152–251) ────────────────────────────────────
+	172: {"ja": "ピチュー", "ko": "피츄", "zh": "皮丘"},
+	174: {"ja": "ププリン", "de": "Fluffeluff", "fr": "Toudoudou", "ko": "푸푸린", "zh": "宝宝丁"},
+	196: {"ja": "エーフィ", "de": "Psiana", "fr": "Mentali", "ko": "에브이", "zh": "太阳伊布"},
+	197: {"ja": "ブラッキー", "de": "Nachtara", "fr": "Noctali", "ko": "블래키", "zh": "月亮伊布"},
+	248: {"ja": "バンギラス", "de": "Despotar", "fr": "Tyranocif", "ko": "마기라스", "zh": "班基拉斯"},
+
+	// ─── Gen III (#252–386) ───────────────────────────────────
+	282: {"ja": "サーナイト", "de": "Guardevoir", "ko": "가디안", "zh": "沙奈朵"},
+
+	// ─── Gen IV (#387–493) ────────────────────────────────────
+	445: {"ja": "ガブリアス", "de": "Knakrack", "fr": "Carchacrok", "ko": "한카리아스", "zh": "烈咬陆鲨"},
+	448: {"ja": "ルカリオ", "ko": "루카리오", "zh": "路卡利欧"},
+	470: {"ja": "リーフィア", "de": "Folipurba", "fr": "Phyllali", "ko": "리피아", "zh": "叶伊布"},
+	471: {"ja": "グレイシア", "de": "Glaziola", "fr": "Givrali", "ko": "글레이시아", "zh": "冰伊布"},
+
+	// ─── Gen VI (#650–721) ────────────────────────────────────
+	700: {"ja": "ニンフィア", "de": "Feelinara", "fr": "Nymphali", "ko": "님피아", "zh": "仙子伊布"},
+
+	// ─── Gen IX (#906–1025) ───────────────────────────────────
+	999:  {"ja": "コレクレー", "de": "Gierspenst", "fr": "Mordudor", "ko": "모으령", "zh": "索财灵"},
+	1000: {"ja": "サーフゴー", "de": "Monetigo", "fr": "Gromago", "ko": "타부자고", "zh": "赛富豪"},
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"math"
+)
+
+// ──────────────── Border Effects ────────────────
+
+type EffectConfig struct {
+	Name   string  `json:"name"`   // gradient, rainbow, wave, sparkle, pulse, static or solid
+	Speed  float64 `json:"speed"`  // cycles per animation loop, 1 for the default pace
+	Spread float64 `json:"spread"` // how far one color stretches along the border, larger is wider (unused by sparkle)
+}
+
+var rainbowColors = []string{"255;0;0", "255;127;0", "255;255;0", "0;255;0", "0;127;255", "139;0;255"}
+
+// borderEffect colors the border cells of a w by h box.
+type borderEffect struct {
+	EffectConfig
+	colors []string
+	w, h   int
+}
+
+// newBorderEffect prepares an effect for the given palette, type colors
+// and dominant color; unknown names fall back to the gradient.
+func newBorderEffect(e EffectConfig, palette, typeRGB []string, dom string) borderEffect {
+	if e.Speed <= 0 {
+		e.Speed = 1
+	}
+	if e.Spread <= 0 {
+		e.Spread = 300
+	}
+	colors := palette
+	switch e.Name {
+	case "rainbow":
+		colors = rainbowColors
+	case "pulse":
+		if len(typeRGB) > 0 {
+			colors = typeRGB
+		}
+	case "solid":
+		colors = []string{dom}
+	case "gradient", "wave", "sparkle", "static":
+	default:
+		e.Name = "gradient"
+	}
+	if len(colors) == 0 {
+		colors = []string{dom}
+	}
+	return borderEffect{EffectConfig: e, colors: colors}
+}
+
+// animated reports whether the effect changes over time.
+func (e borderEffect) animated() bool {
+	return e.Name != "solid" && e.Name != "static"
+}
+
+// cyclic reports whether every cell runs the same color cycle shifted by
+// its delay, which is what HTML exports can reproduce.
+func (e borderEffect) cyclic() bool {
+	return e.Name == "gradient" || e.Name == "rainbow" || e.Name == "wave" || e.Name == "pulse"
+}
+
+// delay is the phase lag of a border cell, 0 to 1.
+func (e borderEffect) delay(row, col int) float64 {
+	switch e.Name {
+	case "rainbow":
+		// Distance travelled clockwise from the top-left corner
+		var p int
+		switch {
+		case row == 0:
+			p = col
+		case col == e.w-1:
+			p = e.w - 1 + row
+		case row == e.h-1:
+			p = 2*(e.w-1) + e.h - 1 - col
+		default:
+			p = 2*(e.w-1) + 2*(e.h-1) - row
+		}
+		return wrap01(float64(p) / e.Spread * 4)
+	case "wave":
+		return wrap01(float64(col+2*row) / e.Spread * 4)
+	case "pulse":
+		return 0
+	}
+	// New colors emerge from the corners and spread inward
+	dx := math.Min(float64(col), float64(e.w-1-col))
+	dy := math.Min(float64(row), float64(e.h-1-row))
+	return math.Sqrt(dx*dx+dy*dy) / e.Spread
+}
+
+// wrap01 wraps x into [0, 1).
+func wrap01(x float64) float64 {
+	x = math.Mod(x, 1)
+	if x < 0 {
+		x++
+	}
+	return x
+}
+
+// phase turns animOffset into the effect's position in its cycle.
+func (e borderEffect) phase(animOffset float64) float64 {
+	return wrap01(animOffset * e.Speed)
+}
+
+// cycleColor is the color of a cell whose shifted phase is o. pulseBase
+// drives the brightness, which for the gradient runs unshifted.
+func (e borderEffect) cycleColor(o, pulseBase, delay float64) string {
+	n := float64(len(e.colors))
+	switch e.Name {
+	case "rainbow":
+		return getInterpolatedRGB(e.colors, o)
+	case "wave":
+		return scaleRGB(getInterpolatedRGB(e.colors, o), 0.8+0.3*math.Sin(o*2.0*math.Pi*n))
+	case "pulse":
+		// The whole border breathes through the type colors
+		return scaleRGB(getInterpolatedRGB(e.colors, o), 0.7+0.4*math.Sin(o*2.0*math.Pi*n))
+	}
+	// Subtler breathing pulse synchronized with the spread
+	return scaleRGB(getInterpolatedRGB(e.colors, o), 0.9+0.2*math.Sin(pulseBase*2.0*math.Pi*n-delay*6.0))
+}
+
+// color returns the "r;g;b" color of a border cell at animOffset.
+func (e borderEffect) color(animOffset float64, row, col int) string {
+	t := e.phase(animOffset)
+	switch e.Name {
+	case "solid":
+		return e.colors[0]
+	case "static":
+		return getInterpolatedRGB(e.colors, e.delay(row, col))
+	case "sparkle":
+		return e.sparkle(animOffset, row, col)
+	}
+	d := e.delay(row, col)
+	return e.cycleColor(wrap01(t-d), t, d)
+}
+
+// sparkleRate is how many twinkles a cell gets per cycle at speed 1.
+const sparkleRate = 12
+
+// sparkle keeps the border in the dominant palette color while random
+// cells briefly flare up in the other palette colors.
+func (e borderEffect) sparkle(animOffset float64, row, col int) string {
+	base := e.colors[0]
+	beat := animOffset * e.Speed * sparkleRate
+	slot := math.Floor(beat)
+	h := cellHash(row, col, int(slot))
+	// About one cell in ten twinkles in each beat
+	if h%10 != 0 {
+		return scaleRGB(base, 0.85)
+	}
+	glow := math.Sin((beat - slot) * math.Pi)
+	flare := e.colors[int(h/10)%len(e.colors)]
+	return scaleRGB(getInterpolatedRGB([]string{base, flare}, glow*0.5), 0.85+0.5*glow)
+}
+
+// cellHash is a cheap deterministic hash of a cell and a time slot.
+func cellHash(row, col, slot int) uint32 {
+	h := uint32(row)*73856093 ^ uint32(col)*19349663 ^ uint32(slot)*83492791
+	h ^= h >> 13
+	h *= 0x5bd1e995
+	h ^= h >> 15
+	return h
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+)
+
+// ──────────────── Evolution Chains ────────────────
+
+// preEvolution maps a species to the one it evolves from.
+var preEvolution = func() map[int]int {
+	pre := make(map[int]int)
+	for from, tos := range evolutions {
+		for _, to := range tos {
+			pre[to] = from
+		}
+	}
+	return pre
+}()
+
+// evolutionRoot returns the first species of the line n belongs to.
+func evolutionRoot(n int) int {
+	for {
+		from, ok := preEvolution[n]
+		if !ok {
+			return n
+		}
+		n = from
+	}
+}
+
+func speciesLabel(n int) string {
+	return localName(n, displayName(dexName(n)))
+}
+
+// evolutionLines lays out the evolution line of species n, or nothing when
+// it neither evolves nor evolves from anything. Each stage follows its
+// predecessor after an arrow; branches after the first go on lines of their
+// own, with their arrows under the first branch's. A stage that would run
+// past width starts a new line instead. Names are styled with base, and
+// species n with hl.
+func evolutionLines(n, width int, base, hl string) []string {
+	root := evolutionRoot(n)
+	if len(evolutions[root]) == 0 {
+		return nil
+	}
+
+	var lines []string
+	var cur strings.Builder
+	curW := 0
+	newLine := func(indent int) {
+		lines = append(lines, cur.String())
+		cur.Reset()
+		cur.WriteString(strings.Repeat(" ", indent))
+		curW = indent
+	}
+
+	var walk func(s int)
+	walk = func(s int) {
+		label := speciesLabel(s)
+		style := base
+		if s == n {
+			style = hl
+		}
+		cur.WriteString(style + label + "\x1b[0m")
+		curW += getVisibleLen(label)
+
+		col := curW
+		for i, next := range evolutions[s] {
+			if i > 0 {
+				newLine(col)
+			} else if curW+3+getVisibleLen(speciesLabel(next)) > width {
+				newLine(2)
+			}
+			cur.WriteString(" → ")
+			curW += 3
+			walk(next)
+		}
+	}
+	walk(root)
+	lines = append(lines, cur.String())
+	return lines
+}
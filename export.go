@@ -0,0 +1,281 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ──────────────── ANSI Cells ────────────────
+
+type Cell struct {
+	Ch     string // rune(s) drawn in the cell, "" for the right half of a wide rune
+	FG, BG string // "r;g;b", empty for the terminal default
+	Bold   bool
+	Keep   bool // stepped over with CUF, whatever is on screen stays
+}
+
+var basicColors = [16]string{
+	"0;0;0", "205;0;0", "0;205;0", "205;205;0", "0;0;238", "205;0;205", "0;205;205", "229;229;229",
+	"127;127;127", "255;0;0", "0;255;0", "255;255;0", "92;92;255", "255;0;255", "0;255;255", "255;255;255",
+}
+
+// parseANSILine splits a line into terminal cells, applying SGR sequences,
+// turning cursor-forward into Keep cells and ignoring any other CSI sequence.
+func parseANSILine(line string) []Cell {
+	var cells []Cell
+	var cur Cell
+	for i := 0; i < len(line); {
+		if line[i] == 0x1b && i+1 < len(line) && line[i+1] == '[' {
+			j := i + 2
+			for j < len(line) && (line[j] < 0x40 || line[j] > 0x7e) {
+				j++
+			}
+			if j >= len(line) {
+				break
+			}
+			switch line[j] {
+			case 'm':
+				applySGR(&cur, line[i+2:j])
+			case 'C':
+				n, err := strconv.Atoi(line[i+2 : j])
+				if err != nil {
+					n = 1
+				}
+				for ; n > 0; n-- {
+					cells = append(cells, Cell{Keep: true})
+				}
+			}
+			i = j + 1
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+
+		switch w := runewidth.RuneWidth(r); {
+		case w == 0 && len(cells) > 0:
+			cells[len(cells)-1].Ch += string(r)
+		case w == 0:
+		default:
+			c := cur
+			c.Ch = string(r)
+			cells = append(cells, c)
+			if w == 2 {
+				c.Ch = ""
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+func applySGR(c *Cell, params string) {
+	ps := strings.Split(params, ";")
+	for i := 0; i < len(ps); i++ {
+		n, _ := strconv.Atoi(ps[i])
+		switch {
+		case n == 0:
+			*c = Cell{}
+		case n == 1:
+			c.Bold = true
+		case n == 22:
+			c.Bold = false
+		case n == 39:
+			c.FG = ""
+		case n == 49:
+			c.BG = ""
+		case n >= 30 && n <= 37:
+			c.FG = basicColors[n-30]
+		case n >= 90 && n <= 97:
+			c.FG = basicColors[n-90+8]
+		case n >= 40 && n <= 47:
+			c.BG = basicColors[n-40]
+		case n >= 100 && n <= 107:
+			c.BG = basicColors[n-100+8]
+		case (n == 38 || n == 48) && i+1 < len(ps):
+			var rgb string
+			if ps[i+1] == "2" && i+4 < len(ps) {
+				rgb = ps[i+2] + ";" + ps[i+3] + ";" + ps[i+4]
+				i += 4
+			} else if ps[i+1] == "5" && i+2 < len(ps) {
+				idx, _ := strconv.Atoi(ps[i+2])
+				rgb = xterm256(idx)
+				i += 2
+			} else {
+				continue
+			}
+			if n == 38 {
+				c.FG = rgb
+			} else {
+				c.BG = rgb
+			}
+		}
+	}
+}
+
+// renderCells turns cells back into an ANSI string, emitting SGR only where
+// the style changes. Cells marked in skip are stepped over with CUF so that
+// whatever is already on screen there stays.
+func renderCells(cells []Cell, skip []bool) string {
+	var b strings.Builder
+	var cur Cell
+	jump := 0
+	for i, c := range cells {
+		if skip != nil && skip[i] {
+			jump++
+			continue
+		}
+		if jump > 0 {
+			fmt.Fprintf(&b, "\x1b[%dC", jump)
+			jump = 0
+		}
+		if c.Ch == "" {
+			continue
+		}
+		if c.FG != cur.FG || c.BG != cur.BG || c.Bold != cur.Bold {
+			// Reset only when an attribute has to be dropped
+			if (cur.Bold && !c.Bold) || (cur.FG != "" && c.FG == "") || (cur.BG != "" && c.BG == "") {
+				b.WriteString("\x1b[0m")
+				cur = Cell{}
+			}
+			if c.Bold && !cur.Bold {
+				b.WriteString("\x1b[1m")
+			}
+			if c.FG != cur.FG {
+				b.WriteString("\x1b[38;2;" + c.FG + "m")
+			}
+			if c.BG != cur.BG {
+				b.WriteString("\x1b[48;2;" + c.BG + "m")
+			}
+			cur = c
+		}
+		b.WriteString(c.Ch)
+	}
+	if jump > 0 {
+		fmt.Fprintf(&b, "\x1b[%dC", jump)
+	}
+	if cur.FG != "" || cur.BG != "" || cur.Bold {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// xterm256 returns the "r;g;b" value of an xterm 256-color index.
+func xterm256(idx int) string {
+	switch {
+	case idx < 16:
+		return basicColors[max(0, idx)]
+	case idx < 232:
+		idx -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return fmt.Sprintf("%d;%d;%d", level(idx/36), level(idx/6%6), level(idx%6))
+	case idx < 256:
+		v := 8 + (idx-232)*10
+		return fmt.Sprintf("%d;%d;%d", v, v, v)
+	}
+	return "255;255;255"
+}
+
+// ──────────────── HTML Export ────────────────
+
+type htmlAnimation struct {
+	Color    func(phase float64) string         // border color over one cycle
+	Duration time.Duration                      // length of one cycle
+	Delay    func(row, col int) (float64, bool) // phase lag of an animated cell
+}
+
+const htmlKeyframeSteps = 60
+
+// exportBackground is the page color exported snippets are drawn on.
+const exportBackground = "26;27;38"
+
+func cssRGB(rgb string) string {
+	return "rgb(" + strings.ReplaceAll(rgb, ";", ",") + ")"
+}
+
+func cellStyle(c Cell) string {
+	var st []string
+	if c.FG != "" {
+		st = append(st, "color:"+cssRGB(c.FG))
+	}
+	if c.BG != "" {
+		st = append(st, "background:"+cssRGB(c.BG))
+	}
+	if c.Bold {
+		st = append(st, "font-weight:bold")
+	}
+	return strings.Join(st, ";")
+}
+
+// exportHTML converts rendered lines into a self-contained <pre> snippet.
+// With anim set, border cells cycle through the border effect using CSS
+// keyframes, each shifted by its delay as in the live view.
+func exportHTML(lines []string, anim *htmlAnimation) string {
+	var b strings.Builder
+
+	if anim != nil {
+		secs := anim.Duration.Seconds()
+		b.WriteString("<style>\n@keyframes shinefetch-shine {\n")
+		for i := 0; i <= htmlKeyframeSteps; i++ {
+			o := float64(i) / htmlKeyframeSteps
+			fmt.Fprintf(&b, "  %.2f%% { color: %s; }\n", o*100, cssRGB(anim.Color(math.Mod(o, 1.0))))
+		}
+		b.WriteString("}\n")
+		fmt.Fprintf(&b, ".shinefetch-shine { font-weight: bold; animation: shinefetch-shine %.2fs linear infinite; }\n", secs)
+		b.WriteString("</style>\n")
+	}
+
+	b.WriteString(`<pre class="shinefetch" style="display:inline-block;margin:0;padding:1em;` +
+		`background:` + cssRGB(exportBackground) + `;color:#e0e0e0;font-family:monospace;line-height:1.15">`)
+
+	for row, line := range lines {
+		cells := parseANSILine(line)
+		for col := 0; col < len(cells); {
+			c := cells[col]
+			if anim != nil {
+				if delay, ok := anim.Delay(row, col); ok {
+					start := -(1.0 - delay) * anim.Duration.Seconds()
+					fmt.Fprintf(&b, `<span class="shinefetch-shine" style="animation-delay:%.3fs">%s</span>`,
+						start, html.EscapeString(c.Ch))
+					col++
+					continue
+				}
+			}
+
+			// Merge the run of identically styled cells into one span
+			var text strings.Builder
+			j := col
+			for j < len(cells) && cellStyle(cells[j]) == cellStyle(c) {
+				if anim != nil {
+					if _, ok := anim.Delay(row, j); ok {
+						break
+					}
+				}
+				text.WriteString(cells[j].Ch)
+				j++
+			}
+			if st := cellStyle(c); st != "" {
+				fmt.Fprintf(&b, `<span style="%s">%s</span>`, st, html.EscapeString(text.String()))
+			} else {
+				b.WriteString(html.EscapeString(text.String()))
+			}
+			col = j
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("</pre>\n")
+	return b.String()
+}
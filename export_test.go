@@ -0,0 +1,54 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseANSILine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []Cell
+	}{
+		{"", nil},
+		{"ab", []Cell{{Ch: "a"}, {Ch: "b"}}},
+		{"\x1b[1;38;2;1;2;3mA\x1b[0mB", []Cell{{Ch: "A", FG: "1;2;3", Bold: true}, {Ch: "B"}}},
+		{"\x1b[31;104mR\x1b[39mS", []Cell{{Ch: "R", FG: "205;0;0", BG: "92;92;255"}, {Ch: "S", BG: "92;92;255"}}},
+		{"漢x", []Cell{{Ch: "漢"}, {Ch: ""}, {Ch: "x"}}},
+		{"\x1b[48;2;9;9;9m字\x1b[0m", []Cell{{Ch: "字", BG: "9;9;9"}, {BG: "9;9;9"}}},
+		{"é", []Cell{{Ch: "é"}}},
+		{"a\x1b[3Cb", []Cell{{Ch: "a"}, {Keep: true}, {Keep: true}, {Keep: true}, {Ch: "b"}}},
+		{"\x1b[Ca", []Cell{{Keep: true}, {Ch: "a"}}},
+		{"a\x1b[K\x1b[2Gb", []Cell{{Ch: "a"}, {Ch: "b"}}},
+		{"a\x1b[38;2", []Cell{{Ch: "a"}}},
+	}
+	for _, tt := range tests {
+		if got := parseANSILine(tt.line); !slices.Equal(got, tt.want) {
+			t.Errorf("parseANSILine(%q) = %+v; want %+v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestRenderCells(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells []Cell
+		skip  []bool
+		want  string
+	}{
+		{"empty", nil, nil, ""},
+		{"plain", []Cell{{Ch: "a"}, {Ch: "b"}}, nil, "ab"},
+		{"shared style", []Cell{{Ch: "a", FG: "1;2;3", Bold: true}, {Ch: "b", FG: "1;2;3", Bold: true}}, nil, "\x1b[1m\x1b[38;2;1;2;3mab\x1b[0m"},
+		{"color change", []Cell{{Ch: "a", FG: "1;2;3"}, {Ch: "b", FG: "4;5;6"}}, nil, "\x1b[38;2;1;2;3ma\x1b[38;2;4;5;6mb\x1b[0m"},
+		{"dropped bold resets", []Cell{{Ch: "a", FG: "1;2;3", Bold: true}, {Ch: "b", FG: "1;2;3"}}, nil, "\x1b[1m\x1b[38;2;1;2;3ma\x1b[0m\x1b[38;2;1;2;3mb\x1b[0m"},
+		{"back to default", []Cell{{Ch: "a", BG: "7;7;7"}, {Ch: "b"}}, nil, "\x1b[48;2;7;7;7ma\x1b[0mb"},
+		{"wide rune", []Cell{{Ch: "漢"}, {Ch: ""}, {Ch: "x"}}, nil, "漢x"},
+		{"skipped cells", []Cell{{Ch: "a"}, {Ch: "b"}, {Ch: "c"}, {Ch: "d"}}, []bool{false, true, true, false}, "a\x1b[2Cd"},
+		{"trailing skip", []Cell{{Ch: "a"}, {Ch: "b"}}, []bool{false, true}, "a\x1b[1C"},
+	}
+	for _, tt := range tests {
+		if got := renderCells(tt.cells, tt.skip); got != tt.want {
+			t.Errorf("%s: renderCells = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package main
+
+import (
+	"strings"
+)
+
+// ──────────────── Forms ────────────────
+
+// Form is a variant of a species with types of its own. Species is a key
+// of PokemonTypes and Name the form as PokeAPI names it ("alola", "mega-x").
+type Form struct {
+	Species, Name string
+}
+
+// FormTypes maps forms to their type(s) where they differ from the species.
+var FormTypes = map[Form][]string{
+	// ─── Alolan forms ─────────────────────────────────────────
+	{"rattata", "alola"}:   {"dark", "normal"},
+	{"raticate", "alola"}:  {"dark", "normal"},
+	{"raichu", "alola"}:    {"electric", "psychic"},
+	{"sandshrew", "alola"}: {"ice", "steel"},
+	{"sandslash", "alola"}: {"ice", "steel"},
+	{"vulpix", "alola"}:    {"ice"},
+	{"ninetales", "alola"}: {"ice", "fairy"},
+	{"diglett", "alola"}:   {"ground", "steel"},
+	{"dugtrio", "alola"}:   {"ground", "steel"},
+	{"meowth", "alola"}:    {"dark"},
+	{"persian", "alola"}:   {"dark"},
+	{"geodude", "alola"}:   {"rock", "electric"},
+	{"graveler", "alola"}:  {"rock", "electric"},
+	{"golem", "alola"}:     {"rock", "electric"},
+	{"grimer", "alola"}:    {"poison", "dark"},
+	{"muk", "alola"}:       {"poison", "dark"},
+	{"exeggutor", "alola"}: {"grass", "dragon"},
+	{"marowak", "alola"}:   {"fire", "ghost"},
+
+	// ─── Galarian forms ───────────────────────────────────────
+	{"meowth", "galar"}:     {"steel"},
+	{"ponyta", "galar"}:     {"psychic"},
+	{"rapidash", "galar"}:   {"psychic", "fairy"},
+	{"slowpoke", "galar"}:   {"psychic"},
+	{"slowbro", "galar"}:    {"poison", "psychic"},
+	{"farfetchd", "galar"}:  {"fighting"},
+	{"weezing", "galar"}:    {"poison", "fairy"},
+	{"mr-mime", "galar"}:    {"ice", "psychic"},
+	{"articuno", "galar"}:   {"psychic", "flying"},
+	{"zapdos", "galar"}:     {"fighting", "flying"},
+	{"moltres", "galar"}:    {"dark", "flying"},
+	{"slowking", "galar"}:   {"poison", "psychic"},
+	{"corsola", "galar"}:    {"ghost"},
+	{"zigzagoon", "galar"}:  {"dark", "normal"},
+	{"linoone", "galar"}:    {"dark", "normal"},
+	{"darumaka", "galar"}:   {"ice"},
+	{"darmanitan", "galar"}: {"ice"},
+	{"yamask", "galar"}:     {"ground", "ghost"},
+	{"stunfisk", "galar"}:   {"ground", "steel"},
+
+	// ─── Hisuian forms ────────────────────────────────────────
+	{"growlithe", "hisui"}:  {"fire", "rock"},
+	{"arcanine", "hisui"}:   {"fire", "rock"},
+	{"voltorb", "hisui"}:    {"electric", "grass"},
+	{"electrode", "hisui"}:  {"electric", "grass"},
+	{"typhlosion", "hisui"}: {"fire", "ghost"},
+	{"qwilfish", "hisui"}:   {"dark", "poison"},
+	{"sneasel", "hisui"}:    {"fighting", "poison"},
+	{"samurott", "hisui"}:   {"water", "dark"},
+	{"lilligant", "hisui"}:  {"grass", "fighting"},
+	{"zorua", "hisui"}:      {"normal", "ghost"},
+	{"zoroark", "hisui"}:    {"normal", "ghost"},
+	{"braviary", "hisui"}:   {"psychic", "flying"},
+	{"sliggoo", "hisui"}:    {"steel", "dragon"},
+	{"goodra", "hisui"}:     {"steel", "dragon"},
+	{"avalugg", "hisui"}:    {"ice", "rock"},
+	{"decidueye", "hisui"}:  {"grass", "fighting"},
+
+	// ─── Paldean forms ────────────────────────────────────────
+	{"wooper", "paldea"}:       {"poison", "ground"},
+	{"tauros", "paldea"}:       {"fighting"},
+	{"tauros", "paldea-blaze"}: {"fighting", "fire"},
+	{"tauros", "paldea-aqua"}:  {"fighting", "water"},
+
+	// ─── Mega Evolutions ──────────────────────────────────────
+	{"venusaur", "mega"}:    {"grass", "poison"},
+	{"charizard", "mega-x"}: {"fire", "dragon"},
+	{"charizard", "mega-y"}: {"fire", "flying"},
+	{"blastoise", "mega"}:   {"water"},
+	{"beedrill", "mega"}:    {"bug", "poison"},
+	{"pidgeot", "mega"}:     {"normal", "flying"},
+	{"alakazam", "mega"}:    {"psychic"},
+	{"slowbro", "mega"}:     {"water", "psychic"},
+	{"gengar", "mega"}:      {"ghost", "poison"},
+	{"kangaskhan", "mega"}:  {"normal"},
+	{"pinsir", "mega"}:      {"bug", "flying"},
+	{"gyarados", "mega"}:    {"water", "dark"},
+	{"aerodactyl", "mega"}:  {"rock", "flying"},
+	{"mewtwo", "mega-x"}:    {"psychic", "fighting"},
+	{"mewtwo", "mega-y"}:    {"psychic"},
+	{"ampharos", "mega"}:    {"electric", "dragon"},
+	{"steelix", "mega"}:     {"steel", "ground"},
+	{"scizor", "mega"}:      {"bug", "steel"},
+	{"heracross", "mega"}:   {"bug", "fighting"},
+	{"houndoom", "mega"}:    {"dark", "fire"},
+	{"tyranitar", "mega"}:   {"rock", "dark"},
+	{"sceptile", "mega"}:    {"grass", "dragon"},
+	{"blaziken", "mega"}:    {"fire", "fighting"},
+	{"swampert", "mega"}:    {"water", "ground"},
+	{"gardevoir", "mega"}:   {"psychic", "fairy"},
+	{"sableye", "mega"}:     {"dark", "ghost"},
+	{"mawile", "mega"}:      {"steel", "fairy"},
+	{"aggron", "mega"}:      {"steel"},
+	{"medicham", "mega"}:    {"fighting", "psychic"},
+	{"manectric", "mega"}:   {"electric"},
+	{"sharpedo", "mega"}:    {"water", "dark"},
+	{"camerupt", "mega"}:    {"fire", "ground"},
+	{"altaria", "mega"}:     {"dragon", "fairy"},
+	{"banette", "mega"}:     {"ghost"},
+	{"absol", "mega"}:       {"dark"},
+	{"glalie", "mega"}:      {"ice"},
+	{"salamence", "mega"}:   {"dragon", "flying"},
+	{"metagross", "mega"}:   {"steel", "psychic"},
+	{"latias", "mega"}:      {"dragon", "psychic"},
+	{"latios", "mega"}:      {"dragon", "psychic"},
+	{"rayquaza", "mega"}:    {"dragon", "flying"},
+	{"lopunny", "mega"}:     {"normal", "fighting"},
+	{"garchomp", "mega"}:    {"dragon", "ground"},
+	{"lucario", "mega"}:     {"fighting", "steel"},
+	{"abomasnow", "mega"}:   {"grass", "ice"},
+	{"gallade", "mega"}:     {"psychic", "fighting"},
+	{"audino", "mega"}:      {"normal", "fairy"},
+	{"diancie", "mega"}:     {"rock", "fairy"},
+
+	// ─── Primal Reversions ────────────────────────────────────
+	{"groudon", "primal"}: {"ground", "fire"},
+	{"kyogre", "primal"}:  {"water"},
+
+	// ─── Alternate forms ──────────────────────────────────────
+	{"castform", "sunny"}:           {"fire"},
+	{"castform", "rainy"}:           {"water"},
+	{"castform", "snowy"}:           {"ice"},
+	{"wormadam", "sandy"}:           {"bug", "ground"},
+	{"wormadam", "trash"}:           {"bug", "steel"},
+	{"shaymin", "sky"}:              {"grass", "flying"},
+	{"darmanitan", "zen"}:           {"fire", "psychic"},
+	{"darmanitan", "galar-zen"}:     {"ice", "fire"},
+	{"meloetta", "pirouette"}:       {"normal", "fighting"},
+	{"hoopa", "unbound"}:            {"psychic", "dark"},
+	{"necrozma", "dusk"}:            {"psychic", "steel"},
+	{"necrozma", "dawn"}:            {"psychic", "ghost"},
+	{"necrozma", "ultra"}:           {"psychic", "dragon"},
+	{"urshifu", "rapid-strike"}:     {"fighting", "water"},
+	{"calyrex", "ice"}:              {"psychic", "ice"},
+	{"calyrex", "shadow"}:           {"psychic", "ghost"},
+	{"ogerpon", "wellspring-mask"}:  {"grass", "water"},
+	{"ogerpon", "hearthflame-mask"}: {"grass", "fire"},
+	{"ogerpon", "cornerstone-mask"}: {"grass", "rock"},
+
+	// ─── Rotom appliances ─────────────────────────────────────
+	{"rotom", "heat"}:  {"electric", "fire"},
+	{"rotom", "wash"}:  {"electric", "water"},
+	{"rotom", "frost"}: {"electric", "ice"},
+	{"rotom", "fan"}:   {"electric", "flying"},
+	{"rotom", "mow"}:   {"electric", "grass"},
+
+	// ─── Oricorio styles ──────────────────────────────────────
+	{"oricorio", "baile"}:   {"fire", "flying"},
+	{"oricorio", "pom-pom"}: {"electric", "flying"},
+	{"oricorio", "pau"}:     {"psychic", "flying"},
+	{"oricorio", "sensu"}:   {"ghost", "flying"},
+}
+
+// formAliases maps the longer form names PokeAPI and sprite sources use to
+// those in FormTypes.
+var formAliases = map[string]string{
+	"alolan":              "alola",
+	"galarian":            "galar",
+	"hisuian":             "hisui",
+	"paldean":             "paldea",
+	"galar-standard":      "galar",
+	"paldea-combat-breed": "paldea",
+	"paldea-blaze-breed":  "paldea-blaze",
+	"paldea-aqua-breed":   "paldea-aqua",
+	"paldea-combat":       "paldea",
+	"dusk-mane":           "dusk",
+	"dawn-wings":          "dawn",
+	"ice-rider":           "ice",
+	"shadow-rider":        "shadow",
+}
+
+// regionalForms maps the adjective of a regional form to its form name.
+var regionalForms = map[string]string{
+	"alolan":   "alola",
+	"galarian": "galar",
+	"hisuian":  "hisui",
+	"paldean":  "paldea",
+}
+
+// parseForm splits a cleaned name as the sprite source prints it into a
+// species and a form, accepting both "vulpix-alola" and "alolan vulpix",
+// "mega charizard x" and "wash rotom". Names of plain species, including
+// hyphenated ones such as "mr-mime", come back with no form. A slug whose
+// form is not in FormTypes, such as "giratina-origin", still yields its
+// species, with the rest as the form.
+func parseForm(name string) (species, form string) {
+	if key, ok := speciesKey(name); ok {
+		return key, ""
+	}
+
+	// Spelled out: "alolan vulpix", "mega charizard x", "heat rotom"
+	if words := strings.Fields(name); len(words) > 1 {
+		rest := strings.Join(words[1:], "-")
+		switch {
+		case regionalForms[words[0]] != "":
+			form = regionalForms[words[0]]
+		case words[0] == "mega":
+			form = "mega"
+			if last := words[len(words)-1]; last == "x" || last == "y" {
+				rest = strings.Join(words[1:len(words)-1], "-")
+				form += "-" + last
+			}
+		case words[0] == "gigantamax" || words[0] == "gmax":
+			form = "gmax"
+		case words[0] == "primal":
+			form = "primal"
+		default:
+			// Appliance-first names such as "wash rotom"
+			last, first := words[len(words)-1], strings.Join(words[:len(words)-1], "-")
+			if _, ok := FormTypes[Form{last, first}]; ok {
+				return last, first
+			}
+		}
+		if key, ok := speciesKey(rest); ok && form != "" {
+			return key, form
+		}
+	}
+
+	// Slugs: "vulpix-alola", "charizard-mega-x", "oricorio-pom-pom". An
+	// unknown form falls back to the longest species the slug starts with,
+	// so that "porygon-z-…" is not taken for Porygon.
+	slug := strings.ReplaceAll(name, " ", "-")
+	fallback, fallbackForm := name, ""
+	for i := strings.Index(slug, "-"); i > 0; {
+		if key, ok := speciesKey(slug[:i]); ok {
+			form := slug[i+1:]
+			if alias, ok := formAliases[form]; ok {
+				form = alias
+			}
+			if _, known := FormTypes[Form{canonicalSpecies(key), form}]; known || form == "gmax" {
+				return key, form
+			}
+			fallback, fallbackForm = key, form
+		}
+		next := strings.Index(slug[i+1:], "-")
+		if next < 0 {
+			break
+		}
+		i += 1 + next
+	}
+	return fallback, fallbackForm
+}
+
+// canonicalSpecies returns the National Dex spelling of a PokemonTypes key,
+// which FormTypes uses: "farfetch'd" is "farfetchd".
+func canonicalSpecies(key string) string {
+	if n := dexNumber(key); n > 0 {
+		return dexName(n)
+	}
+	return key
+}
+
+// formTitle capitalizes each word of a form name: "pom-pom" is "Pom-Pom".
+func formTitle(s string) string {
+	parts := strings.Split(s, "-")
+	for i, p := range parts {
+		if p != "" {
+			parts[i] = strings.ToUpper(p[:1]) + p[1:]
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// formName is how a form is called in the games: "Alolan Vulpix", "Mega
+// Charizard X", "Wash Rotom" or "Oricorio (Pom-Pom Style)". Regional, Mega,
+// Primal and Gigantamax forms are named in the current language; forms
+// missing from FormTypes keep their name in brackets.
+func formName(species, form string) string {
+	name := localName(dexNumber(species), displayName(species))
+	if _, known := FormTypes[Form{canonicalSpecies(species), form}]; !known {
+		switch {
+		case form == "gmax", form == "mega", strings.HasPrefix(form, "mega-"):
+		default:
+			return name + " (" + formTitle(form) + ")"
+		}
+	}
+	for adj, region := range regionalForms {
+		if form == region {
+			return trf(formTitle(adj)+" %s", name)
+		}
+		if strings.HasPrefix(form, region+"-") {
+			return trf(formTitle(adj)+" %s", name) + " (" + formTitle(strings.TrimPrefix(form, region+"-")) + ")"
+		}
+	}
+	switch {
+	case form == "mega":
+		return trf("Mega %s", name)
+	case strings.HasPrefix(form, "mega-"):
+		return trf("Mega %s", name+" "+strings.ToUpper(strings.TrimPrefix(form, "mega-")))
+	case form == "gmax":
+		return trf("Gigantamax %s", name)
+	case form == "primal":
+		return trf("Primal %s", name)
+	case species == "rotom":
+		return formTitle(form) + " " + name
+	case species == "oricorio":
+		return name + " (" + trf("%s Style", formTitle(form)) + ")"
+	case strings.HasSuffix(form, "-mask"):
+		return name + " (" + trf("%s Mask", formTitle(strings.TrimSuffix(form, "-mask"))) + ")"
+	}
+	return name + " (" + formTitle(form) + ")"
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseForm(t *testing.T) {
+	tests := []struct {
+		name          string
+		species, form string
+	}{
+		{"pikachu", "pikachu", ""},
+		{"mr-mime", "mr-mime", ""},
+		{"mr. mime", "mr. mime", ""},
+		{"ho-oh", "ho-oh", ""},
+		{"porygon-z", "porygon-z", ""},
+		{"mr-mime-galar", "mr-mime", "galar"},
+		{"vulpix-alola", "vulpix", "alola"},
+		{"alolan vulpix", "vulpix", "alola"},
+		{"galarian farfetch'd", "farfetch'd", "galar"},
+		{"farfetchd-galar", "farfetchd", "galar"},
+		{"charizard-mega-x", "charizard", "mega-x"},
+		{"mega charizard x", "charizard", "mega-x"},
+		{"mega gengar", "gengar", "mega"},
+		{"charizard-gmax", "charizard", "gmax"},
+		{"tauros-paldea-blaze-breed", "tauros", "paldea-blaze"},
+		{"tauros-paldea-combat-breed", "tauros", "paldea"},
+		{"darmanitan-galar-standard", "darmanitan", "galar"},
+		{"wash rotom", "rotom", "wash"},
+		{"rotom-wash", "rotom", "wash"},
+		{"oricorio-pom-pom", "oricorio", "pom-pom"},
+		{"pikachu-alola", "pikachu", "alola"},
+		{"pikachu-alola-cap", "pikachu", "alola-cap"},
+		{"giratina-origin", "giratina", "origin"},
+		{"lycanroc-midnight", "lycanroc", "midnight"},
+		{"tauros-paldea-combat", "tauros", "paldea"},
+		{"porygon-z-test", "porygon-z", "test"},
+		{"groudon-primal", "groudon", "primal"},
+		{"primal kyogre", "kyogre", "primal"},
+		{"necrozma-dusk-mane", "necrozma", "dusk"},
+		{"calyrex-shadow-rider", "calyrex", "shadow"},
+		{"urshifu-rapid-strike", "urshifu", "rapid-strike"},
+		{"ogerpon-wellspring-mask", "ogerpon", "wellspring-mask"},
+		{"darmanitan-galar-zen", "darmanitan", "galar-zen"},
+		{"heat", "heat", ""},
+		{"missingno-x", "missingno-x", ""},
+	}
+	for _, tt := range tests {
+		species, form := parseForm(tt.name)
+		if species != tt.species || form != tt.form {
+			t.Errorf("parseForm(%q) = %q, %q; want %q, %q", tt.name, species, form, tt.species, tt.form)
+		}
+	}
+}
+
+func TestLookupEntryForms(t *testing.T) {
+	tests := []struct {
+		name   string
+		number int
+		form   string
+		types  []string
+	}{
+		{"Vulpix-Alola", 37, "alola", []string{"ice"}},
+		{"Galarian Farfetch'd", 83, "galar", []string{"fighting"}},
+		{"Mr-Mime-Galar", 122, "galar", []string{"ice", "psychic"}},
+		{"Rotom-Wash", 479, "wash", []string{"electric", "water"}},
+		{"Charizard-Gmax", 6, "gmax", []string{"fire", "flying"}},
+		{"Mr. Mime", 122, "", []string{"psychic", "fairy"}},
+		{"Giratina-Origin", 487, "origin", []string{"ghost", "dragon"}},
+		{"Pikachu-Alola", 25, "alola", []string{"electric"}},
+		{"Lycanroc-Midnight", 745, "midnight", []string{"rock"}},
+		{"Tauros-Paldea-Combat", 128, "paldea", []string{"fighting"}},
+		{"Groudon-Primal", 383, "primal", []string{"ground", "fire"}},
+		{"Castform-Rainy", 351, "rainy", []string{"water"}},
+		{"Wormadam-Trash", 413, "trash", []string{"bug", "steel"}},
+		{"Shaymin-Sky", 492, "sky", []string{"grass", "flying"}},
+		{"Darmanitan-Zen", 555, "zen", []string{"fire", "psychic"}},
+		{"Meloetta-Pirouette", 648, "pirouette", []string{"normal", "fighting"}},
+		{"Hoopa-Unbound", 720, "unbound", []string{"psychic", "dark"}},
+		{"Necrozma-Dawn", 800, "dawn", []string{"psychic", "ghost"}},
+		{"Urshifu-Rapid-Strike", 892, "rapid-strike", []string{"fighting", "water"}},
+		{"Calyrex-Ice", 898, "ice", []string{"psychic", "ice"}},
+		{"Ogerpon-Hearthflame-Mask", 1017, "hearthflame-mask", []string{"grass", "fire"}},
+	}
+	for _, tt := range tests {
+		e, ok := lookupEntry(tt.name)
+		if !ok || e.Number != tt.number || e.Form != tt.form || !slices.Equal(e.Types, tt.types) {
+			t.Errorf("lookupEntry(%q) = #%d %q %v, %v; want #%d %q %v", tt.name, e.Number, e.Form, e.Types, ok, tt.number, tt.form, tt.types)
+		}
+	}
+}
+
+func TestFormName(t *testing.T) {
+	tests := []struct {
+		species, form, want string
+	}{
+		{"vulpix", "alola", "Alolan Vulpix"},
+		{"mr-mime", "galar", "Galarian Mr. Mime"},
+		{"farfetch'd", "galar", "Galarian Farfetch'd"},
+		{"tauros", "paldea-blaze", "Paldean Tauros (Blaze)"},
+		{"charizard", "mega-x", "Mega Charizard X"},
+		{"gengar", "mega", "Mega Gengar"},
+		{"charizard", "gmax", "Gigantamax Charizard"},
+		{"rotom", "wash", "Wash Rotom"},
+		{"oricorio", "pom-pom", "Oricorio (Pom-Pom Style)"},
+		{"groudon", "primal", "Primal Groudon"},
+		{"ogerpon", "wellspring-mask", "Ogerpon (Wellspring Mask)"},
+		{"darmanitan", "galar-zen", "Galarian Darmanitan (Zen)"},
+		{"giratina", "origin", "Giratina (Origin)"},
+		{"pikachu", "alola-cap", "Pikachu (Alola-Cap)"},
+	}
+	for _, tt := range tests {
+		if got := formName(tt.species, tt.form); got != tt.want {
+			t.Errorf("formName(%q, %q) = %q; want %q", tt.species, tt.form, got, tt.want)
+		}
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"pikachu", "Pikachu"},
+		{"mr-mime", "Mr. Mime"},
+		{"mime-jr", "Mime Jr."},
+		{"nidoran-f", "Nidoran♀"},
+		{"ho-oh", "Ho-Oh"},
+		{"great-tusk", "Great Tusk"},
+		{"tapu-koko", "Tapu Koko"},
+		{"porygon2", "Porygon2"},
+	}
+	for _, tt := range tests {
+		if got := displayName(tt.name); got != tt.want {
+			t.Errorf("displayName(%q) = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
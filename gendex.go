@@ -0,0 +1,316 @@
+//go:build ignore
+
+// gendex rewrites dexdata.go with the Pokédex details of every species in
+// the National Dex, fetched from PokeAPI. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	apiBase  = "https://pokeapi.co/api/v2/"
+	dexCount = 1025
+)
+
+var (
+	generationStarts = []int{1, 152, 252, 387, 494, 650, 722, 810, 906}
+	romanGenerations = []string{"I", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX"}
+)
+
+// generationHeader is the comment opening a generation, as in pokedata.go.
+func generationHeader(g int) string {
+	last := dexCount
+	if g+1 < len(generationStarts) {
+		last = generationStarts[g+1] - 1
+	}
+	h := fmt.Sprintf("// ─── Gen %s (#%d–%d) ", romanGenerations[g], generationStarts[g], last)
+	return h + strings.Repeat("─", 61-len([]rune(h)))
+}
+
+// nameLanguages maps the PokeAPI languages species names are taken from to
+// those of the language setting.
+var nameLanguages = map[string]string{
+	"ja-Hrkt": "ja", "de": "de", "fr": "fr", "es": "es", "it": "it", "ko": "ko", "zh-Hans": "zh",
+}
+
+type species struct {
+	Names []struct {
+		Name     string `json:"name"`
+		Language struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"names"`
+	Genera []struct {
+		Genus    string `json:"genus"`
+		Language struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"genera"`
+	FlavorTextEntries []struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+		} `json:"language"`
+	} `json:"flavor_text_entries"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+}
+
+type chainLink struct {
+	Species struct {
+		URL string `json:"url"`
+	} `json:"species"`
+	EvolvesTo []chainLink `json:"evolves_to"`
+}
+
+type pokemon struct {
+	Height    int `json:"height"` // decimetres
+	Weight    int `json:"weight"` // hectograms
+	Abilities []struct {
+		Ability struct {
+			Name string `json:"name"`
+		} `json:"ability"`
+		IsHidden bool `json:"is_hidden"`
+		Slot     int  `json:"slot"`
+	} `json:"abilities"`
+	Stats []struct {
+		BaseStat int `json:"base_stat"`
+	} `json:"stats"`
+}
+
+var client = &http.Client{Timeout: 30 * time.Second}
+
+func fetch(path string, v any) error {
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		var resp *http.Response
+		resp, err = client.Get(apiBase + path)
+		if err != nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			err = fmt.Errorf("%s: %s", path, resp.Status)
+			time.Sleep(time.Second)
+			continue
+		}
+		err = json.NewDecoder(resp.Body).Decode(v)
+		resp.Body.Close()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// urlID returns the number at the end of a PokeAPI resource URL.
+func urlID(url string) int {
+	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	n, _ := strconv.Atoi(parts[len(parts)-1])
+	return n
+}
+
+// addEvolutions records each stage of a chain under the one before it.
+func addEvolutions(link chainLink, evolutions map[int][]int) {
+	from := urlID(link.Species.URL)
+	for _, next := range link.EvolvesTo {
+		if to := urlID(next.Species.URL); to <= dexCount {
+			evolutions[from] = append(evolutions[from], to)
+		}
+		addEvolutions(next, evolutions)
+	}
+}
+
+// generationIndex returns the index in generationStarts of the generation
+// National Dex number n belongs to.
+func generationIndex(n int) int {
+	g := 0
+	for i, start := range generationStarts {
+		if n >= start {
+			g = i
+		}
+	}
+	return g
+}
+
+// abilityName turns an ability slug such as "solar-power" into "Solar Power".
+func abilityName(slug string) string {
+	words := strings.Split(slug, "-")
+	for i, w := range words {
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// cleanFlavor joins the line breaks and page breaks of game text.
+func cleanFlavor(s string) string {
+	s = strings.NewReplacer("­\n", "", "­", "", "\f", " ", "\n", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func main() {
+	var out bytes.Buffer
+	out.WriteString(`package main
+
+//go:generate go run gendex.go
+
+// dexDetails holds the Pokédex details of species by National Dex number.
+// Generated from PokeAPI by gendex.go; do not edit.
+var dexDetails = map[int]DexEntry{
+`)
+	evolutions := make(map[int][]int)
+	names := make(map[int]string)
+	chains := make(map[string]bool)
+	for n := 1; n <= dexCount; n++ {
+		if n == generationStarts[generationIndex(n)] {
+			if n > 1 {
+				out.WriteString("\n")
+			}
+			out.WriteString("\t" + generationHeader(generationIndex(n)) + "\n")
+		}
+
+		var s species
+		var p pokemon
+		if err := fetch(fmt.Sprintf("pokemon-species/%d", n), &s); err != nil {
+			fmt.Fprintln(os.Stderr, "gendex:", err)
+			os.Exit(1)
+		}
+		if err := fetch(fmt.Sprintf("pokemon/%d", n), &p); err != nil {
+			fmt.Fprintln(os.Stderr, "gendex:", err)
+			os.Exit(1)
+		}
+
+		genus := ""
+		for _, g := range s.Genera {
+			if g.Language.Name == "en" {
+				genus = g.Genus
+			}
+		}
+		// The newest English entry comes last
+		flavor := ""
+		for _, f := range s.FlavorTextEntries {
+			if f.Language.Name == "en" {
+				flavor = cleanFlavor(f.FlavorText)
+			}
+		}
+		// Only names spelled differently from the English one are kept
+		english := ""
+		for _, nm := range s.Names {
+			if nm.Language.Name == "en" {
+				english = nm.Name
+			}
+		}
+		var local []string
+		for _, nm := range s.Names {
+			if lang, ok := nameLanguages[nm.Language.Name]; ok && nm.Name != english {
+				local = append(local, fmt.Sprintf("%q: %q", lang, nm.Name))
+			}
+		}
+		if len(local) > 0 {
+			names[n] = strings.Join(local, ", ")
+		}
+
+		var abilities []string
+		for _, hidden := range []bool{false, true} {
+			for _, a := range p.Abilities {
+				if a.IsHidden == hidden {
+					abilities = append(abilities, fmt.Sprintf("%q", abilityName(a.Ability.Name)))
+				}
+			}
+		}
+		if url := s.EvolutionChain.URL; url != "" && !chains[url] {
+			chains[url] = true
+			var chain struct {
+				Chain chainLink `json:"chain"`
+			}
+			if err := fetch(strings.TrimPrefix(url, apiBase), &chain); err != nil {
+				fmt.Fprintln(os.Stderr, "gendex:", err)
+				os.Exit(1)
+			}
+			addEvolutions(chain.Chain, evolutions)
+		}
+
+		var stats [6]int
+		for i := 0; i < len(stats) && i < len(p.Stats); i++ {
+			stats[i] = p.Stats[i].BaseStat
+		}
+
+		fmt.Fprintf(&out, "\t%d: {Genus: %q, Height: %.1f, Weight: %.1f, Abilities: []string{%s}, Stats: [6]int{%d, %d, %d, %d, %d, %d}, Flavor: %q},\n",
+			n, genus, float64(p.Height)/10, float64(p.Weight)/10, strings.Join(abilities, ", "),
+			stats[0], stats[1], stats[2], stats[3], stats[4], stats[5], flavor)
+		fmt.Fprintf(os.Stderr, "\r%d/%d", n, dexCount)
+	}
+	out.WriteString("}\n")
+	fmt.Fprintln(os.Stderr)
+
+	out.WriteString(`
+// evolutions maps a species to the species it evolves into, by National
+// Dex number.
+var evolutions = map[int][]int{
+`)
+	lastGen := -1
+	for n := 1; n <= dexCount; n++ {
+		tos, ok := evolutions[n]
+		if !ok {
+			continue
+		}
+		if g := generationIndex(n); g != lastGen {
+			if lastGen >= 0 {
+				out.WriteString("\n")
+			}
+			out.WriteString("\t" + generationHeader(g) + "\n")
+			lastGen = g
+		}
+		var ids []string
+		for _, to := range tos {
+			ids = append(ids, strconv.Itoa(to))
+		}
+		fmt.Fprintf(&out, "\t%d: {%s},\n", n, strings.Join(ids, ", "))
+	}
+	out.WriteString("}\n")
+
+	out.WriteString(`
+// speciesNames holds the names of species in other languages by National
+// Dex number and language, leaving out those spelled as in English.
+var speciesNames = map[int]map[string]string{
+`)
+	lastGen = -1
+	for n := 1; n <= dexCount; n++ {
+		local, ok := names[n]
+		if !ok {
+			continue
+		}
+		if g := generationIndex(n); g != lastGen {
+			if lastGen >= 0 {
+				out.WriteString("\n")
+			}
+			out.WriteString("\t" + generationHeader(g) + "\n")
+			lastGen = g
+		}
+		fmt.Fprintf(&out, "\t%d: {%s},\n", n, local)
+	}
+	out.WriteString("}\n")
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gendex:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile("dexdata.go", src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "gendex:", err)
+		os.Exit(1)
+	}
+}
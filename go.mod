@@ -2,9 +2,9 @@ module shinefetch
 
 go 1.25.0
 
-require github.com/mattn/go-runewidth v0.0.20
-
 require (
-	github.com/clipperhouse/uax29/v2 v2.2.0 // indirect
-	github.com/tailscale/hujson v0.0.0-20250605163823-992244df8c5a // indirect
+	github.com/mattn/go-runewidth v0.0.20
+	github.com/tailscale/hujson v0.0.0-20250605163823-992244df8c5a
 )
+
+require github.com/clipperhouse/uax29/v2 v2.2.0 // indirect
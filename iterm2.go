@@ -0,0 +1,20 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+)
+
+// ──────────────── iTerm2 Inline Images ────────────────
+
+// encodeITerm2 renders img with the iTerm2 inline image protocol
+// (OSC 1337 File=), stretched over exactly cols×rows cells.
+func encodeITerm2(img image.Image, cols, rows int) string {
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return fmt.Sprintf("\x1b]1337;File=inline=1;size=%d;width=%d;height=%d;preserveAspectRatio=0:%s\a",
+		buf.Len(), cols, rows, base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ──────────────── Key Bindings ────────────────
+
+type keyAction int
+
+const (
+	keyNone   keyAction = iota
+	keyQuit             // close the view and drop the key
+	keyPass             // close the view and hand the key to the shell
+	keyReroll           // r: a new random encounter
+	keyShiny            // s: preview the other form of the same Pokemon
+	keyNext             // n, →: next National Dex number
+	keyPrev             // p, ←: previous National Dex number
+	keyInfo             // i: flip the box between the overview and details
+	keyHelp             // ?: show or hide the key help
+)
+
+// keyHelpText and mouseHelpText list the bindings for the help footer.
+// They are keys of labels as well.
+const (
+	keyHelpText   = "r reroll · s shiny · n/p dex · i info · q quit"
+	mouseHelpText = "sprite rerolls · type shows matchups · wheel pages"
+)
+
+// splitKeys cuts what one read from the tty returned into single key
+// presses: escape sequences, mouse reports among them, stay whole and
+// everything else is one rune each. Keys typed while the view is busy
+// arrive together.
+func splitKeys(buf []byte) [][]byte {
+	var keys [][]byte
+	for i := 0; i < len(buf); {
+		n := 1
+		switch {
+		case buf[i] == 0x1b && i+1 < len(buf) && buf[i+1] == '[':
+			// CSI: parameter and intermediate bytes up to a final byte
+			n = 2
+			for i+n < len(buf) && (buf[i+n] < 0x40 || buf[i+n] > 0x7e) {
+				n++
+			}
+			n = min(n+1, len(buf)-i)
+		case buf[i] == 0x1b && i+2 < len(buf) && buf[i+1] == 'O':
+			// SS3, as sent for arrows in application cursor mode
+			n = 3
+		case buf[i] == 0x1b && i+1 < len(buf) && buf[i+1] != 0x1b:
+			// Alt held with a key
+			_, size := utf8.DecodeRune(buf[i+1:])
+			n = 1 + size
+		case buf[i] != 0x1b:
+			_, n = utf8.DecodeRune(buf[i:])
+		}
+		keys = append(keys, buf[i:i+n])
+		i += n
+	}
+	return keys
+}
+
+// parseKey maps the bytes of one key press to an action. Outside
+// interactive mode every key closes the view, as do unbound keys inside it.
+func parseKey(key []byte, interactive bool) keyAction {
+	if len(key) == 0 {
+		return keyNone
+	}
+	if !interactive {
+		if passableKey(key) != nil {
+			return keyPass
+		}
+		return keyQuit
+	}
+	switch string(key) {
+	case "q", "\x1b", "\r", "\n":
+		return keyQuit
+	case "r":
+		return keyReroll
+	case "s":
+		return keyShiny
+	case "n", "\x1b[C", "\x1bOC":
+		return keyNext
+	case "p", "\x1b[D", "\x1bOD":
+		return keyPrev
+	case "i":
+		return keyInfo
+	case "?":
+		return keyHelp
+	}
+	if key[0] == 0x1b {
+		// Other escape sequences, such as function keys
+		return keyNone
+	}
+	if passableKey(key) != nil {
+		return keyPass
+	}
+	return keyQuit
+}
+
+// ──────────────── Mouse ────────────────
+
+// SGR mouse reporting: button presses (1000) in the extended format (1006),
+// which has no limit on coordinates.
+const (
+	mouseOn  = "\x1b[?1000h\x1b[?1006h"
+	mouseOff = "\x1b[?1006l\x1b[?1000l"
+)
+
+// Mouse buttons as reported, with the modifier bits cleared.
+const (
+	mouseLeft      = 0
+	mouseWheelUp   = 64
+	mouseWheelDown = 65
+)
+
+type mouseEvent struct {
+	Button int
+	X, Y   int // 0-based screen cell
+	Press  bool
+}
+
+// parseMouse reads the SGR mouse reports (ESC [ < b ; x ; y M, or m on
+// release) in key. It reports false when key is not a mouse report.
+func parseMouse(key []byte) ([]mouseEvent, bool) {
+	s := string(key)
+	if !strings.HasPrefix(s, "\x1b[<") {
+		return nil, false
+	}
+	var events []mouseEvent
+	for _, report := range strings.Split(s, "\x1b[<")[1:] {
+		end := strings.IndexAny(report, "Mm")
+		if end < 0 {
+			continue
+		}
+		f := strings.Split(report[:end], ";")
+		if len(f) != 3 {
+			continue
+		}
+		b, err1 := strconv.Atoi(f[0])
+		x, err2 := strconv.Atoi(f[1])
+		y, err3 := strconv.Atoi(f[2])
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		// Shift, meta and control are bits 4, 8 and 16
+		events = append(events, mouseEvent{Button: b &^ 28, X: x - 1, Y: y - 1, Press: report[end] == 'M'})
+	}
+	return events, true
+}
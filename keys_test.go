@@ -0,0 +1,95 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSplitKeys(t *testing.T) {
+	tests := []struct {
+		buf  string
+		want []string
+	}{
+		{"", nil},
+		{"n", []string{"n"}},
+		{"nnn", []string{"n", "n", "n"}},
+		{"\x1b[C\x1b[Dq", []string{"\x1b[C", "\x1b[D", "q"}},
+		{"\x1bOCr", []string{"\x1bOC", "r"}},
+		{"\x1b[<0;12;5M\x1b[<0;12;5mn", []string{"\x1b[<0;12;5M", "\x1b[<0;12;5m", "n"}},
+		{"\x1b[15~i", []string{"\x1b[15~", "i"}},
+		{"\x1b", []string{"\x1b"}},
+		{"\x1b\x1b", []string{"\x1b", "\x1b"}},
+		{"\x1bx", []string{"\x1bx"}},
+		{"\x1b[12", []string{"\x1b[12"}},
+		{"\x1bO", []string{"\x1bO"}},
+		{"éß", []string{"é", "ß"}},
+		{"\r\n", []string{"\r", "\n"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, k := range splitKeys([]byte(tt.buf)) {
+			got = append(got, string(k))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("splitKeys(%q) = %q; want %q", tt.buf, got, tt.want)
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		key         string
+		interactive bool
+		want        keyAction
+	}{
+		{"", true, keyNone},
+		{"q", true, keyQuit},
+		{"\x1b", true, keyQuit},
+		{"\r", true, keyQuit},
+		{"r", true, keyReroll},
+		{"s", true, keyShiny},
+		{"n", true, keyNext},
+		{"\x1b[C", true, keyNext},
+		{"\x1bOC", true, keyNext},
+		{"p", true, keyPrev},
+		{"\x1b[D", true, keyPrev},
+		{"i", true, keyInfo},
+		{"?", true, keyHelp},
+		{"\x1b[15~", true, keyNone},
+		{"l", true, keyPass},
+		{"\x03", true, keyQuit},
+		{"n", false, keyPass},
+		{"q", false, keyPass},
+		{"\r", false, keyQuit},
+		{"\x1b[C", false, keyQuit},
+	}
+	for _, tt := range tests {
+		if got := parseKey([]byte(tt.key), tt.interactive); got != tt.want {
+			t.Errorf("parseKey(%q, %v) = %d; want %d", tt.key, tt.interactive, got, tt.want)
+		}
+	}
+}
+
+func TestParseMouse(t *testing.T) {
+	tests := []struct {
+		key    string
+		events []mouseEvent
+		ok     bool
+	}{
+		{"n", nil, false},
+		{"\x1b[C", nil, false},
+		{"\x1b[<0;12;5M", []mouseEvent{{Button: mouseLeft, X: 11, Y: 4, Press: true}}, true},
+		{"\x1b[<0;12;5m", []mouseEvent{{Button: mouseLeft, X: 11, Y: 4}}, true},
+		{"\x1b[<65;1;1M", []mouseEvent{{Button: mouseWheelDown, Press: true}}, true},
+		{"\x1b[<20;3;4M", []mouseEvent{{Button: mouseLeft, X: 2, Y: 3, Press: true}}, true},
+		{"\x1b[<64;2;2M\x1b[<64;2;2M", []mouseEvent{{Button: mouseWheelUp, X: 1, Y: 1, Press: true}, {Button: mouseWheelUp, X: 1, Y: 1, Press: true}}, true},
+		{"\x1b[<0;x;5M", nil, true},
+		{"\x1b[<0;12", nil, true},
+	}
+	for _, tt := range tests {
+		events, ok := parseMouse([]byte(tt.key))
+		if ok != tt.ok || !slices.Equal(events, tt.events) {
+			t.Errorf("parseMouse(%q) = %+v, %v; want %+v, %v", tt.key, events, ok, tt.events, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+)
+
+// ──────────────── Kitty Graphics ────────────────
+
+const kittyImageID = 7125
+
+// kittyTransmit uploads img to the terminal as a PNG under id without
+// displaying it. The payload is split into the 4096-byte chunks the
+// protocol requires.
+func kittyTransmit(img image.Image, id int) string {
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	data := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	var b strings.Builder
+	for i := 0; i < len(data); i += 4096 {
+		end := min(i+4096, len(data))
+		more := 0
+		if end < len(data) {
+			more = 1
+		}
+		if i == 0 {
+			fmt.Fprintf(&b, "\x1b_Ga=t,f=100,i=%d,q=2,m=%d;%s\x1b\\", id, more, data[i:end])
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, data[i:end])
+		}
+	}
+	return b.String()
+}
+
+// kittyPlace displays a transmitted image at the cursor, scaled to
+// cols×rows cells, without moving the cursor. Reusing the placement id
+// replaces the previous placement instead of stacking a new one.
+func kittyPlace(id, cols, rows int) string {
+	return fmt.Sprintf("\x1b_Ga=p,i=%d,p=1,c=%d,r=%d,C=1,q=2\x1b\\", id, cols, rows)
+}
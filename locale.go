@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ──────────────── Localisation ────────────────
+
+// languages are the values the language setting takes besides "en".
+var languages = []string{"ja", "de", "fr", "es", "it", "ko", "zh"}
+
+// language is the language species names and labels are shown in, set from
+// the config before anything is drawn.
+var language = "en"
+
+// setLanguage switches to lang, staying on English for languages with no
+// translations.
+func setLanguage(lang string) {
+	language = "en"
+	if lang = strings.ToLower(lang); slices.Contains(languages, lang) {
+		language = lang
+	}
+}
+
+// labels translates the labels and words of the box, keyed by the English.
+// Entries holding a verb are format strings.
+var labels = map[string]map[string]string{
+	"ja": {
+		"Trainer": "トレーナー", "Species": "ポケモン", "Type": "タイプ", "Caught": "捕獲数", "Colors": "カラー",
+		"Dex No.": "図鑑番号", "Genus": "分類", "Abilities": "特性", "Base Stat Total": "種族値合計", "Evolution": "進化",
+		"Weak to": "弱点", "Resists": "耐性", "Immune": "無効", "None": "なし",
+		"Dex": "図鑑", "Region": "地方", "Size": "大きさ", "Form": "すがた", "Entry": "説明", "Palette": "パレット",
+		"Atk": "こうげき", "Def": "ぼうぎょ", "SpA": "とくこう", "SpD": "とくぼう", "Spe": "すばやさ",
+		"Keys": "キー", "Mouse": "マウス",
+		"POKéDEX": "ポケモン図鑑", "DETAILS": "詳細", "MATCHUPS": "相性",
+		"SHINY %s!!": "色違いの%s!!", "%d Shiny Pokemon": "色違い %d匹", "Generation %s": "第%s世代",
+		"Unknown": "不明", "Normal": "通常", "Shiny": "色違い", "%s (preview)": "%s (プレビュー)",
+		"Alolan %s": "%s (アローラのすがた)", "Galarian %s": "%s (ガラルのすがた)", "Hisuian %s": "%s (ヒスイのすがた)", "Paldean %s": "%s (パルデアのすがた)",
+		"Mega %s": "メガ%s", "Gigantamax %s": "キョダイマックス%s", "Primal %s": "ゲンシ%s",
+		"%s Style": "%sスタイル", "%s Mask": "%sのめん",
+		keyHelpText: "r 再抽選 · s 色違い · n/p 図鑑 · i 情報 · q 終了", mouseHelpText: "画像で再抽選 · タイプで相性 · ホイールでページ",
+	},
+	"de": {
+		"Trainer": "Trainer", "Species": "Pokémon", "Type": "Typ", "Caught": "Gefangen", "Colors": "Farben",
+		"Dex No.": "Dex-Nr.", "Genus": "Kategorie", "Abilities": "Fähigkeiten", "Base Stat Total": "Basiswerte", "Evolution": "Entwicklung",
+		"Weak to": "Schwach gegen", "Resists": "Resistent", "Immune": "Immun", "None": "Keine",
+		"Dex": "Dex", "Region": "Region", "Size": "Größe", "Form": "Form", "Entry": "Eintrag", "Palette": "Palette",
+		"HP": "KP", "Atk": "Angr", "Def": "Vert", "SpA": "SpAng", "SpD": "SpVert", "Spe": "Init",
+		"Keys": "Tasten", "Mouse": "Maus",
+		"POKéDEX": "POKéDEX", "DETAILS": "DETAILS", "MATCHUPS": "SCHWÄCHEN",
+		"SHINY %s!!": "SCHILLERNDES %s!!", "%d Shiny Pokemon": "%d schillernde Pokémon", "Generation %s": "Generation %s",
+		"Unknown": "Unbekannt", "Normal": "Normal", "Shiny": "Schillernd", "%s (preview)": "%s (Vorschau)",
+		"Alolan %s": "Alola-%s", "Galarian %s": "Galar-%s", "Hisuian %s": "Hisui-%s", "Paldean %s": "Paldea-%s",
+		"Mega %s": "Mega-%s", "Gigantamax %s": "Gigadynamax-%s", "Primal %s": "Proto-%s",
+		"%s Style": "%s-Stil", "%s Mask": "%s-Maske",
+		keyHelpText: "r neu · s schillernd · n/p Dex · i Info · q Ende", mouseHelpText: "Sprite: neu · Typ: Schwächen · Rad: Seiten",
+	},
+	"fr": {
+		"Trainer": "Dresseur", "Species": "Espèce", "Type": "Type", "Caught": "Capturés", "Colors": "Couleurs",
+		"Dex No.": "N° Pokédex", "Genus": "Catégorie", "Abilities": "Talents", "Base Stat Total": "Total stats", "Evolution": "Évolution",
+		"Weak to": "Faible contre", "Resists": "Résiste à", "Immune": "Immunisé", "None": "Aucun",
+		"Dex": "Pokédex", "Region": "Région", "Size": "Taille", "Form": "Forme", "Entry": "Description", "Palette": "Palette",
+		"HP": "PV", "Atk": "Atq", "Def": "Déf", "SpA": "AtqS", "SpD": "DéfS", "Spe": "Vit",
+		"Keys": "Touches", "Mouse": "Souris",
+		"POKéDEX": "POKéDEX", "DETAILS": "DÉTAILS", "MATCHUPS": "AFFINITÉS",
+		"SHINY %s!!": "%s CHROMATIQUE !!", "%d Shiny Pokemon": "%d Pokémon chromatiques", "Generation %s": "Génération %s",
+		"Unknown": "Inconnu", "Normal": "Normal", "Shiny": "Chromatique", "%s (preview)": "%s (aperçu)",
+		"Alolan %s": "%s d'Alola", "Galarian %s": "%s de Galar", "Hisuian %s": "%s de Hisui", "Paldean %s": "%s de Paldea",
+		"Mega %s": "Méga-%s", "Gigantamax %s": "%s Gigamax", "Primal %s": "Primo-%s",
+		"%s Style": "Style %s", "%s Mask": "Masque %s",
+		keyHelpText: "r relancer · s chromatique · n/p Pokédex · i infos · q quitter", mouseHelpText: "sprite : relancer · type : affinités · molette : pages",
+	},
+	"es": {
+		"Trainer": "Entrenador", "Species": "Especie", "Type": "Tipo", "Caught": "Capturados", "Colors": "Colores",
+		"Dex No.": "N.º Pokédex", "Genus": "Categoría", "Abilities": "Habilidades", "Base Stat Total": "Total", "Evolution": "Evolución",
+		"Weak to": "Débil a", "Resists": "Resiste", "Immune": "Inmune", "None": "Ninguno",
+		"Dex": "Pokédex", "Region": "Región", "Size": "Tamaño", "Form": "Forma", "Entry": "Entrada", "Palette": "Paleta",
+		"HP": "PS", "Atk": "Atq", "Def": "Def", "SpA": "AtEsp", "SpD": "DefEsp", "Spe": "Vel",
+		"Keys": "Teclas", "Mouse": "Ratón",
+		"POKéDEX": "POKéDEX", "DETAILS": "DETALLES", "MATCHUPS": "EFICACIA",
+		"SHINY %s!!": "¡¡%s VARIOCOLOR!!", "%d Shiny Pokemon": "%d Pokémon variocolor", "Generation %s": "Generación %s",
+		"Unknown": "Desconocido", "Normal": "Normal", "Shiny": "Variocolor", "%s (preview)": "%s (vista previa)",
+		"Alolan %s": "%s de Alola", "Galarian %s": "%s de Galar", "Hisuian %s": "%s de Hisui", "Paldean %s": "%s de Paldea",
+		"Mega %s": "Mega-%s", "Gigantamax %s": "%s Gigamax", "Primal %s": "%s Primigenio",
+		"%s Style": "Estilo %s", "%s Mask": "Máscara %s",
+		keyHelpText: "r otro · s variocolor · n/p Pokédex · i info · q salir", mouseHelpText: "sprite: otro · tipo: eficacia · rueda: páginas",
+	},
+	"it": {
+		"Trainer": "Allenatore", "Species": "Specie", "Type": "Tipo", "Caught": "Catturati", "Colors": "Colori",
+		"Dex No.": "N. Pokédex", "Genus": "Categoria", "Abilities": "Abilità", "Base Stat Total": "Totale", "Evolution": "Evoluzione",
+		"Weak to": "Debole a", "Resists": "Resiste a", "Immune": "Immune", "None": "Nessuno",
+		"Dex": "Pokédex", "Region": "Regione", "Size": "Dimensioni", "Form": "Forma", "Entry": "Voce", "Palette": "Tavolozza",
+		"HP": "PS", "Atk": "Att", "Def": "Dif", "SpA": "AttSp", "SpD": "DifSp", "Spe": "Vel",
+		"Keys": "Tasti", "Mouse": "Mouse",
+		"POKéDEX": "POKéDEX", "DETAILS": "DETTAGLI", "MATCHUPS": "EFFICACIA",
+		"SHINY %s!!": "%s CROMATICO!!", "%d Shiny Pokemon": "%d Pokémon cromatici", "Generation %s": "Generazione %s",
+		"Unknown": "Sconosciuto", "Normal": "Normale", "Shiny": "Cromatico", "%s (preview)": "%s (anteprima)",
+		"Alolan %s": "%s di Alola", "Galarian %s": "%s di Galar", "Hisuian %s": "%s di Hisui", "Paldean %s": "%s di Paldea",
+		"Mega %s": "Mega %s", "Gigantamax %s": "%s Gigamax", "Primal %s": "Archeo %s",
+		"%s Style": "Stile %s", "%s Mask": "Maschera %s",
+		keyHelpText: "r altro · s cromatico · n/p Pokédex · i info · q esci", mouseHelpText: "sprite: altro · tipo: efficacia · rotella: pagine",
+	},
+	"ko": {
+		"Trainer": "트레이너", "Species": "포켓몬", "Type": "타입", "Caught": "포획", "Colors": "색상",
+		"Dex No.": "도감 번호", "Genus": "분류", "Abilities": "특성", "Base Stat Total": "종족값 합계", "Evolution": "진화",
+		"Weak to": "약점", "Resists": "반감", "Immune": "무효", "None": "없음",
+		"Dex": "도감", "Region": "지방", "Size": "크기", "Form": "폼", "Entry": "설명", "Palette": "팔레트",
+		"Atk": "공격", "Def": "방어", "SpA": "특공", "SpD": "특방", "Spe": "스피드",
+		"Keys": "키", "Mouse": "마우스",
+		"POKéDEX": "포켓몬 도감", "DETAILS": "상세", "MATCHUPS": "상성",
+		"SHINY %s!!": "색이 다른 %s!!", "%d Shiny Pokemon": "색이 다른 포켓몬 %d마리", "Generation %s": "%s세대",
+		"Unknown": "알 수 없음", "Normal": "일반", "Shiny": "색이 다른", "%s (preview)": "%s (미리보기)",
+		"Alolan %s": "알로라 %s", "Galarian %s": "가라르 %s", "Hisuian %s": "히스이 %s", "Paldean %s": "팔데아 %s",
+		"Mega %s": "메가%s", "Gigantamax %s": "거다이맥스 %s", "Primal %s": "원시%s",
+		"%s Style": "%s스타일", "%s Mask": "%s가면",
+		keyHelpText: "r 다시 · s 색이 다른 · n/p 도감 · i 정보 · q 종료", mouseHelpText: "이미지: 다시 · 타입: 상성 · 휠: 페이지",
+	},
+	"zh": {
+		"Trainer": "训练家", "Species": "宝可梦", "Type": "属性", "Caught": "捕获", "Colors": "颜色",
+		"Dex No.": "图鉴编号", "Genus": "分类", "Abilities": "特性", "Base Stat Total": "种族值总和", "Evolution": "进化",
+		"Weak to": "弱点", "Resists": "抵抗", "Immune": "免疫", "None": "无",
+		"Dex": "图鉴", "Region": "地区", "Size": "体型", "Form": "形态", "Entry": "介绍", "Palette": "调色板",
+		"Atk": "攻击", "Def": "防御", "SpA": "特攻", "SpD": "特防", "Spe": "速度",
+		"Keys": "按键", "Mouse": "鼠标",
+		"POKéDEX": "宝可梦图鉴", "DETAILS": "详情", "MATCHUPS": "属性相性",
+		"SHINY %s!!": "异色%s!!", "%d Shiny Pokemon": "异色宝可梦 %d 只", "Generation %s": "第%s世代",
+		"Unknown": "未知", "Normal": "普通", "Shiny": "异色", "%s (preview)": "%s（预览）",
+		"Alolan %s": "阿罗拉%s", "Galarian %s": "伽勒尔%s", "Hisuian %s": "洗翠%s", "Paldean %s": "帕底亚%s",
+		"Mega %s": "超级%s", "Gigantamax %s": "超极巨%s", "Primal %s": "原始%s",
+		"%s Style": "%s风格", "%s Mask": "%s面具",
+		keyHelpText: "r 重抽 · s 异色 · n/p 图鉴 · i 信息 · q 退出", mouseHelpText: "图像：重抽 · 属性：相性 · 滚轮：翻页",
+	},
+}
+
+// tr translates a label or word into the current language, leaving it in
+// English when there is no translation.
+func tr(s string) string {
+	if t, ok := labels[language][s]; ok {
+		return t
+	}
+	return s
+}
+
+// trf translates a format string and fills it in.
+func trf(format string, a ...any) string {
+	return fmt.Sprintf(tr(format), a...)
+}
+
+// typeNames are the names of the types in each language, in typeOrder.
+var typeNames = map[string][18]string{
+	"ja": {"ノーマル", "ほのお", "みず", "でんき", "くさ", "こおり", "かくとう", "どく", "じめん", "ひこう", "エスパー", "むし", "いわ", "ゴースト", "ドラゴン", "あく", "はがね", "フェアリー"},
+	"de": {"Normal", "Feuer", "Wasser", "Elektro", "Pflanze", "Eis", "Kampf", "Gift", "Boden", "Flug", "Psycho", "Käfer", "Gestein", "Geist", "Drache", "Unlicht", "Stahl", "Fee"},
+	"fr": {"Normal", "Feu", "Eau", "Électrik", "Plante", "Glace", "Combat", "Poison", "Sol", "Vol", "Psy", "Insecte", "Roche", "Spectre", "Dragon", "Ténèbres", "Acier", "Fée"},
+	"es": {"Normal", "Fuego", "Agua", "Eléctrico", "Planta", "Hielo", "Lucha", "Veneno", "Tierra", "Volador", "Psíquico", "Bicho", "Roca", "Fantasma", "Dragón", "Siniestro", "Acero", "Hada"},
+	"it": {"Normale", "Fuoco", "Acqua", "Elettro", "Erba", "Ghiaccio", "Lotta", "Veleno", "Terra", "Volante", "Psico", "Coleottero", "Roccia", "Spettro", "Drago", "Buio", "Acciaio", "Folletto"},
+	"ko": {"노말", "불꽃", "물", "전기", "풀", "얼음", "격투", "독", "땅", "비행", "에스퍼", "벌레", "바위", "고스트", "드래곤", "악", "강철", "페어리"},
+	"zh": {"一般", "火", "水", "电", "草", "冰", "格斗", "毒", "地面", "飞行", "超能力", "虫", "岩石", "幽灵", "龙", "恶", "钢", "妖精"},
+}
+
+// typeLabel is the name of type t in the current language.
+func typeLabel(t string) string {
+	if names, ok := typeNames[language]; ok {
+		if i := slices.Index(typeOrder, t); i >= 0 {
+			return names[i]
+		}
+	}
+	return strings.ToUpper(t[:1]) + t[1:]
+}
+
+// localName is the name of species n in the current language, or english
+// when it has none there.
+func localName(n int, english string) string {
+	if name, ok := speciesNames[n][language]; ok {
+		return name
+	}
+	return english
+}
+
+// localIndex maps the lower-cased names of species in every language to
+// their National Dex number, so names pokeget prints in another language
+// are recognised.
+var localIndex = func() map[string]int {
+	idx := make(map[string]int)
+	for n, names := range speciesNames {
+		for _, name := range names {
+			idx[strings.ToLower(name)] = n
+		}
+	}
+	return idx
+}()
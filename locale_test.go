@@ -0,0 +1,66 @@
+package main
+
+import "testing"
+
+func TestLocalizedNames(t *testing.T) {
+	defer setLanguage("en")
+	tests := []struct {
+		lang, species, form, want string
+	}{
+		{"de", "vulpix", "alola", "Alola-Vulpix"},
+		{"de", "charizard", "mega-x", "Mega-Glurak X"},
+		{"fr", "charizard", "gmax", "Dracaufeu Gigamax"},
+		{"ja", "charizard", "mega-y", "メガリザードン Y"},
+		{"zh", "meowth", "galar", "伽勒尔喵喵"},
+		{"de", "rotom", "wash", "Wash Rotom"},
+		{"de", "oricorio", "pom-pom", "Oricorio (Pom-Pom-Stil)"},
+		{"fr", "ogerpon", "wellspring-mask", "Ogerpon (Masque Wellspring)"},
+		{"es", "groudon", "primal", "Groudon Primigenio"},
+	}
+	for _, tt := range tests {
+		setLanguage(tt.lang)
+		if got := formName(tt.species, tt.form); got != tt.want {
+			t.Errorf("%s: formName(%q, %q) = %q; want %q", tt.lang, tt.species, tt.form, got, tt.want)
+		}
+	}
+}
+
+func TestLookupLocalizedName(t *testing.T) {
+	for name, want := range map[string]int{"ピカチュウ": 25, "Glurak": 6, "évoli": 133, "이브이": 133, "喷火龙": 6} {
+		if e, ok := lookupEntry(name); !ok || e.Number != want {
+			t.Errorf("lookupEntry(%q) = #%d, %v; want #%d", name, e.Number, ok, want)
+		}
+	}
+}
+
+func TestLabelsComplete(t *testing.T) {
+	keys := make(map[string]bool)
+	for _, l := range labels {
+		for k := range l {
+			keys[k] = true
+		}
+	}
+	// Labels that read the same in a language may be left out
+	same := map[string]bool{"HP": true}
+	for _, lang := range languages {
+		for k := range keys {
+			if _, ok := labels[lang][k]; !ok && !same[k] {
+				t.Errorf("%s: no translation for %q", lang, k)
+			}
+		}
+	}
+}
+
+func TestTranslatedUI(t *testing.T) {
+	defer setLanguage("en")
+	setLanguage("de")
+	if got := statRows(DexEntry{Stats: [6]int{45, 49, 49, 65, 65, 45}})[1].K; got != "  KP" {
+		t.Errorf("first stat label = %q; want %q", got, "  KP")
+	}
+	if got := trf("%s (preview)", "Normal"); got != "Normal (Vorschau)" {
+		t.Errorf("preview = %q", got)
+	}
+	if tr(keyHelpText) == keyHelpText || tr(mouseHelpText) == mouseHelpText {
+		t.Error("help footer not translated")
+	}
+}
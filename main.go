@@ -3,6 +3,7 @@ package main
 // Shinefetch 󰄳
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"math"
 	"math/rand"
@@ -11,7 +12,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"regexp"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -25,27 +26,71 @@ import (
 
 // ──────────────── Constants & Types ────────────────
 
+const (
+	animCycle      = 29400 * time.Millisecond // one animation loop at animation_speed 1
+	minSideScale   = 0.5                      // below this share of sprite_scale, stack the sprite above the box
+	minStackedRows = 3                        // shortest sprite worth showing above the box
+	flavorWidth    = 44                       // columns Pokédex entries wrap at on the details page
+	evolutionWidth = 44                       // columns an evolution line runs before it wraps, unless the box is narrower
+)
+
 type Config struct {
-	ShinyChance   int    `json:"shiny_chance"`    // 1 in X chance for shiny
-	BoxStyle      string `json:"box_style"`       // rounded, sharp, double, heavy
-	Gap           int    `json:"gap"`             // space between pokemon and box
-	Animation     bool   `json:"animation"`       // always animate border if true
-	TrainerName   string `json:"trainer_name"`    // override user name
-	Align         string `json:"align"`           // center or left
-	PrintAndExit  bool   `json:"print_and_exit"`  // print once and quit (no interactive)
-	ShinyBoxStyle string `json:"shiny_box_style"` // border style for shiny pokemon
+	ShinyChance       int          `json:"shiny_chance"`       // 1 in X chance for shiny
+	BoxStyle          string       `json:"box_style"`          // rounded, sharp, double, heavy
+	Gap               int          `json:"gap"`                // space between pokemon and box
+	Animation         bool         `json:"animation"`          // animate normal borders too, when normal_effect moves
+	TrainerName       string       `json:"trainer_name"`       // override user name
+	Align             string       `json:"align"`              // center or left
+	PrintAndExit      bool         `json:"print_and_exit"`     // print once and quit (no interactive)
+	ShinyBoxStyle     string       `json:"shiny_box_style"`    // border style for shiny pokemon
+	SpriteProtocol    string       `json:"sprite_protocol"`    // halfblock, kitty, sixel or iterm2
+	SpriteScale       float64      `json:"sprite_scale"`       // sprite size multiplier, shrunk further to fit
+	SpriteFilter      string       `json:"sprite_filter"`      // area or nearest
+	MaxBoxWidth       int          `json:"max_box_width"`      // widest the box may grow, 0 for the terminal width
+	Fullscreen        bool         `json:"fullscreen"`         // draw on the alternate screen, restored on exit
+	KeyPassthrough    string       `json:"key_passthrough"`    // tiocsti or none
+	Interactive       bool         `json:"interactive"`        // keys reroll, step through the dex and flip pages instead of quitting
+	DexRows           []string     `json:"dex_rows"`           // extra Pokédex rows: number, genus, abilities, total, evolution, stats and matchups
+	Language          string       `json:"language"`           // en, ja, de, fr, es, it, ko or zh for species names and labels
+	Overflow          string       `json:"overflow"`           // truncate or wrap values that do not fit
+	Background        string       `json:"background"`         // auto, dark or light
+	MinContrast       float64      `json:"min_contrast"`       // WCAG contrast ratio UI colors keep against the background
+	ShinyEffect       EffectConfig `json:"shiny_effect"`       // border effect for shiny encounters
+	NormalEffect      EffectConfig `json:"normal_effect"`      // border effect for normal encounters, animated when animation is on
+	FPS               int          `json:"fps"`                // frames drawn per second while animating
+	AnimationSpeed    float64      `json:"animation_speed"`    // animation speed multiplier
+	AnimationDuration float64      `json:"animation_duration"` // seconds to animate before exiting, 0 to wait for a key
+	Sparkles          bool         `json:"sparkles"`           // sparkles around shiny sprites
+	SparkleDensity    float64      `json:"sparkle_density"`    // share of the cells around the sprite lit at a time
 }
 
 func loadConfig() Config {
 	c := Config{
-		ShinyChance:   20,
-		BoxStyle:      "rounded",
-		Gap:           8,
-		Animation:     true,
-		TrainerName:   "",
-		Align:         "center",
-		PrintAndExit:  false,
-		ShinyBoxStyle: "double",
+		ShinyChance:    20,
+		BoxStyle:       "rounded",
+		Gap:            8,
+		Animation:      true,
+		TrainerName:    "",
+		Align:          "center",
+		PrintAndExit:   false,
+		ShinyBoxStyle:  "double",
+		SpriteProtocol: "halfblock",
+		SpriteScale:    1.0,
+		SpriteFilter:   "area",
+		MaxBoxWidth:    0,
+		Fullscreen:     false,
+		KeyPassthrough: "tiocsti",
+		Interactive:    false,
+		Language:       "en",
+		Overflow:       "truncate",
+		Background:     "auto",
+		MinContrast:    4.5,
+		ShinyEffect:    EffectConfig{Name: "gradient", Speed: 1, Spread: 300},
+		NormalEffect:   EffectConfig{Name: "solid", Speed: 1, Spread: 300},
+		FPS:            20,
+		AnimationSpeed: 1.0,
+		Sparkles:       true,
+		SparkleDensity: 0.04,
 	}
 	home, _ := os.UserHomeDir()
 	path := filepath.Join(home, ".config", "shinefetch", "settings.jsonc")
@@ -97,9 +142,13 @@ var boxStyles = map[string]BoxStyle{
 }
 
 type Row struct {
-	K, V  string
-	IsSep bool
-	IsRaw bool
+	K, V    string
+	IsSep   bool
+	IsRaw   bool
+	IsCont  bool // wrapped continuation of the row above
+	IsTypes bool // type badges, clicked to show matchups
+	Stat    int  // base stat drawn as a bar across the value column
+	Evolves int  // species whose evolution line is laid out here once the value width is known
 }
 
 type Stats struct {
@@ -136,20 +185,44 @@ func stripAnsi(s string) string {
 	return re.ReplaceAllString(s, "")
 }
 
+// Box drawing and Nerd Font icons are East Asian ambiguous, which
+// go-runewidth counts as two cells under a CJK locale; terminals draw them
+// in one, like everywhere else.
+func init() {
+	runewidth.DefaultCondition = &runewidth.Condition{StrictEmojiNeutral: true}
+}
+
 func getVisibleLen(s string) int {
 	return runewidth.StringWidth(stripAnsi(s))
 }
 
-func getTermSize() (int, int) {
-	ws := struct{ Row, Col, Xpixel, Ypixel uint16 }{}
+type winsize struct{ Row, Col, Xpixel, Ypixel uint16 }
+
+func getWinsize() winsize {
+	ws := winsize{}
 	syscall.Syscall(syscall.SYS_IOCTL,
 		uintptr(syscall.Stdin), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	return ws
+}
+
+func getTermSize() (int, int) {
+	ws := getWinsize()
 	if ws.Col == 0 {
 		return 80, 24
 	}
 	return int(ws.Col), int(ws.Row)
 }
 
+// getCellSize returns the pixel size of one character cell, or zeros when
+// the terminal does not report its pixel dimensions.
+func getCellSize() (int, int) {
+	ws := getWinsize()
+	if ws.Col == 0 || ws.Row == 0 {
+		return 0, 0
+	}
+	return int(ws.Xpixel) / int(ws.Col), int(ws.Ypixel) / int(ws.Row)
+}
+
 func cleanName(name string) string {
 	var b strings.Builder
 	for _, r := range name {
@@ -161,26 +234,8 @@ func cleanName(name string) string {
 }
 
 func lookupTypes(name string) []string {
-	target := cleanName(name)
-	if target == "" || target == "unknown" {
-		return nil
-	}
-
-	if t, ok := PokemonTypes[target]; ok {
-		return t
-	}
-	if t, ok := PokemonTypes[strings.ReplaceAll(target, " ", "-")]; ok {
-		return t
-	}
-	if t, ok := PokemonTypes[strings.ReplaceAll(target, "-", " ")]; ok {
-		return t
-	}
-	noDots := strings.ReplaceAll(target, ".", "")
-	if t, ok := PokemonTypes[noDots]; ok {
-		return t
-	}
-
-	return nil
+	e, _ := lookupEntry(name)
+	return e.Types
 }
 
 func getInterpolatedRGB(colors []string, offset float64) string {
@@ -217,6 +272,21 @@ func getInterpolatedRGB(colors []string, offset float64) string {
 	return fmt.Sprintf("%d;%d;%d", r, g, b)
 }
 
+// scaleRGB multiplies each channel of an "r;g;b" color by p, clamped to 0–255.
+func scaleRGB(rgb string, p float64) string {
+	parts := strings.Split(rgb, ";")
+	if len(parts) < 3 {
+		return rgb
+	}
+	r, _ := strconv.Atoi(parts[0])
+	g, _ := strconv.Atoi(parts[1])
+	b, _ := strconv.Atoi(parts[2])
+	r = int(math.Max(0, math.Min(255, float64(r)*p)))
+	g = int(math.Max(0, math.Min(255, float64(g)*p)))
+	b = int(math.Max(0, math.Min(255, float64(b)*p)))
+	return fmt.Sprintf("%d;%d;%d", r, g, b)
+}
+
 func formatTypeBadges(types []string, reset string) string {
 	var badges []string
 	for _, t := range types {
@@ -224,33 +294,185 @@ func formatTypeBadges(types []string, reset string) string {
 		if !ok {
 			rgb = "180;180;180"
 		}
-		label := strings.ToUpper(t[:1]) + t[1:]
+		label := typeLabel(t)
 		badge := fmt.Sprintf("\x1b[1;38;2;255;255;255m\x1b[48;2;%sm %s %s", rgb, label, reset)
 		badges = append(badges, badge)
 	}
 	return strings.Join(badges, " ")
 }
 
+// dexRows builds the optional Pokédex rows named in keys, leaving out
+// those the Pokédex has no data for.
+func dexRows(e DexEntry, keys []string) []Row {
+	var rows []Row
+	for _, k := range keys {
+		switch k {
+		case "number":
+			if e.Number > 0 {
+				rows = append(rows, Row{K: "󰈚 " + tr("Dex No."), V: fmt.Sprintf("#%04d", e.Number)})
+			}
+		case "genus":
+			if e.Genus != "" {
+				rows = append(rows, Row{K: "󰌪 " + tr("Genus"), V: e.Genus})
+			}
+		case "abilities":
+			if len(e.Abilities) > 0 {
+				rows = append(rows, Row{K: "󰓥 " + tr("Abilities"), V: strings.Join(e.Abilities, ", ")})
+			}
+		case "total":
+			if t := e.statTotal(); t > 0 {
+				rows = append(rows, Row{K: "󰄧 " + tr("Base Stat Total"), V: strconv.Itoa(t)})
+			}
+		}
+	}
+	return rows
+}
+
+// statLabels name the base stats in the order of DexEntry.Stats.
+var statLabels = [6]string{"HP", "Atk", "Def", "SpA", "SpD", "Spe"}
+
+// statRows is the base stats section: a separator and one bar per stat,
+// or nothing when the stats are unknown.
+func statRows(e DexEntry) []Row {
+	if e.statTotal() == 0 {
+		return nil
+	}
+	rows := []Row{{IsSep: true}}
+	for i, s := range e.Stats {
+		// Indented to line up with the labels that follow an icon
+		rows = append(rows, Row{K: "  " + tr(statLabels[i]), Stat: s})
+	}
+	return rows
+}
+
+// Eighth blocks, so bars grow in steps of an eighth of a cell
+var barEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// statBar draws a base stat w columns wide: a bar in barC scaled so that
+// 255 fills it, then the value in numC.
+func statBar(stat, w int, barC, numC, reset string) string {
+	barW := max(1, w-4)
+	eighths := int(math.Round(float64(min(stat, 255)) / 255 * float64(barW*8)))
+	bar := strings.Repeat("█", eighths/8) + barEighths[eighths%8]
+	return barC + bar + reset + strings.Repeat(" ", max(0, barW-getVisibleLen(bar))) + numC + fmt.Sprintf(" %3d", stat) + reset
+}
+
+// evolutionRow holds the place of the evolution line of species n, or is
+// nothing when n neither evolves nor evolves from anything.
+func evolutionRow(n int) []Row {
+	if evolutionLines(n, evolutionWidth, "", "") == nil {
+		return nil
+	}
+	return []Row{{K: "󰐱 " + tr("Evolution"), Evolves: n}}
+}
+
+// evolutionRows lays out the evolution line of species n w columns wide,
+// with names styled by base and n by hl.
+func evolutionRows(n, w int, base, hl string) []Row {
+	var rows []Row
+	for i, line := range evolutionLines(n, w, base, hl) {
+		r := Row{V: line, IsRaw: true, IsCont: i > 0}
+		if i == 0 {
+			r.K = "󰐱 " + tr("Evolution")
+		}
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+// layoutEvolutions replaces the evolution rows among rows with their
+// lines laid out w columns wide.
+func layoutEvolutions(rows []Row, w int, base, hl string) []Row {
+	var out []Row
+	for _, r := range rows {
+		if r.Evolves > 0 {
+			out = append(out, evolutionRows(r.Evolves, w, base, hl)...)
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// matchupSections are the rows of the type matchups and the multipliers
+// each one lists.
+var matchupSections = []struct {
+	Icon, K string
+	Mults   []float64
+}{
+	{"󱐋", "Weak to", []float64{4, 2}},
+	{"󰒘", "Resists", []float64{0.5, 0.25}},
+	{"󰒃", "Immune", []float64{0}},
+}
+
+// matchupRows lists the types a Pokemon of the given types is weak to,
+// resists and is immune to as badges, four to a row, each group led by
+// its multiplier.
+func matchupRows(types []string) []Row {
+	groups := matchups(types)
+	var rows []Row
+	for _, s := range matchupSections {
+		k := s.Icon + " " + tr(s.K)
+		for _, ml := range multiplierLabels {
+			if !slices.Contains(s.Mults, ml.M) {
+				continue
+			}
+			ts := groups[ml.M]
+			for i := 0; i < len(ts); i += 4 {
+				label := ml.Label
+				if i > 0 {
+					label = ""
+				}
+				label += strings.Repeat(" ", 3-getVisibleLen(label))
+				rows = append(rows, Row{K: k, V: label + formatTypeBadges(ts[i:min(i+4, len(ts))], "\x1b[0m"), IsRaw: true, IsCont: k == ""})
+				k = ""
+			}
+		}
+		if k != "" {
+			rows = append(rows, Row{K: k, V: tr("None")})
+		}
+	}
+	return rows
+}
+
 // ──────────────── Main Logic ────────────────
 
 func main() {
+	var opts options
+	flag.StringVar(&opts.Export, "export", "", "print a snapshot in the given format (html) and exit")
+	flag.BoolVar(&opts.Animate, "animate", false, "include the shiny border animation in exports")
+	flag.StringVar(&opts.Record, "record", "", "record the animation to an asciicast v2 file and exit")
+	flag.DurationVar(&opts.RecordDuration, "record-duration", 0, "length of the recording (default: one full animation cycle)")
+	flag.BoolVar(&opts.EmitKey, "emit-key", false, "print the key that closed the view on stdout for shell integration")
+	flag.Parse()
+
 	rand.Seed(time.Now().UnixNano())
 	cfg := loadConfig()
-
-	// 1. Fetch Sprite & Name
-	isShiny := rand.Intn(cfg.ShinyChance) == 0
-	stats := loadStats()
-	if isShiny {
-		stats.ShinyCount++
-		saveStats(stats)
+	setLanguage(cfg.Language)
+
+	// Exports draw on their own page background instead of the terminal's
+	sess := &session{background: exportBackground}
+	if opts.Export == "" {
+		// The query turns echo off for a moment, so the tty is put under
+		// the session's care first
+		sess.attach()
+		sess.background = detectBackground(cfg.Background, sess.tty)
 	}
+	defer sess.close()
 
-	args := []string{"random"}
-	if isShiny {
-		args = append(args, "--shiny")
+	req := encounterRequest{}
+	for {
+		next, ok := run(&cfg, opts, req, sess)
+		if !ok {
+			return
+		}
+		req = next
 	}
+}
 
-	// Try to resolve pokeget path
+// findPokeget resolves the pokeget binary, looking in common install
+// locations when it is not on the PATH.
+func findPokeget() string {
 	pokegetPath := "pokeget"
 	if _, err := exec.LookPath(pokegetPath); err != nil {
 		// Try common locations
@@ -268,8 +490,32 @@ func main() {
 			}
 		}
 	}
+	return pokegetPath
+}
+
+// run shows one encounter. In the live view it returns the encounter to
+// show next when a key asks for one, and ok false once the view closes.
+func run(cfg *Config, opts options, req encounterRequest, sess *session) (next encounterRequest, ok bool) {
+	// 1. Fetch Sprite & Name
+	isShiny := rand.Intn(cfg.ShinyChance) == 0
+	if req.Dex > 0 {
+		isShiny = req.Shiny
+	}
+	stats := loadStats()
+	if isShiny && !req.Preview {
+		stats.ShinyCount++
+		saveStats(stats)
+	}
 
-	cmd := exec.Command(pokegetPath, args...)
+	args := []string{"random"}
+	if req.Dex > 0 {
+		args = []string{strconv.Itoa(req.Dex)}
+	}
+	if isShiny {
+		args = append(args, "--shiny")
+	}
+
+	cmd := exec.Command(findPokeget(), args...)
 	out, err := cmd.CombinedOutput()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running pokeget: %v\n", err)
@@ -277,7 +523,7 @@ func main() {
 	pokeOut := string(out)
 
 	rawLines := strings.Split(strings.ReplaceAll(pokeOut, "\r", ""), "\n")
-	pokemonName := "Unknown"
+	pokemonName := tr("Unknown")
 	nameIdx := -1
 
 	for i, line := range rawLines {
@@ -306,7 +552,14 @@ func main() {
 	}
 
 	// 2. Data Retrieval
-	types := lookupTypes(pokemonName)
+	entry, _ := lookupEntry(pokemonName)
+	types := entry.Types
+	switch {
+	case entry.Form != "":
+		pokemonName = formName(entry.Name, entry.Form)
+	case entry.Number > 0 && language != "en":
+		pokemonName = localName(entry.Number, pokemonName)
+	}
 	reset := "\x1b[0m"
 
 	// 3. Fastfetch Info
@@ -338,84 +591,20 @@ func main() {
 	}
 
 	// 4. Color Extraction
-	reColor := regexp.MustCompile(`48;2;(\d+;\d+;\d+)m`)
-	matches := reColor.FindAllStringSubmatch(pokeOut, -1)
-	counts := make(map[string]int)
-	for _, m := range matches {
-		counts[m[1]]++
-	}
-	type CC struct {
-		C string
-		N int
-	}
-	var valid, all []CC
-	for c, n := range counts {
-		all = append(all, CC{c, n})
-		rgb := strings.Split(c, ";")
-		if len(rgb) == 3 {
-			r, _ := strconv.Atoi(rgb[0])
-			g, _ := strconv.Atoi(rgb[1])
-			b, _ := strconv.Atoi(rgb[2])
-			if (r < 30 && g < 30 && b < 30) || (r > 240 && g > 240 && b > 240) {
-				continue
-			}
-			maxC, minC := r, r
-			if g > maxC {
-				maxC = g
-			}
-			if b > maxC {
-				maxC = b
-			}
-			if g < minC {
-				minC = g
-			}
-			if b < minC {
-				minC = b
-			}
-			if maxC-minC < 15 {
-				continue
-			}
-			valid = append(valid, CC{c, n})
-		}
-	}
-	sort.Slice(valid, func(i, j int) bool { return valid[i].N > valid[j].N })
-	sort.Slice(all, func(i, j int) bool { return all[i].N > all[j].N })
-
-	dom, sec, ter := "32;252;0", "0;255;255", "255;0;255"
-	if len(valid) > 0 {
-		dom = valid[0].C
-		if len(valid) > 1 {
-			sec = valid[1].C
-		} else if len(all) > 1 {
-			sec = all[1].C
-		}
-		if len(valid) > 2 {
-			ter = valid[2].C
-		} else if len(all) > 2 {
-			ter = all[2].C
-		}
-	}
+	background := sess.background
+	palette := extractPalette(decodeSprite(pokeLines))
+	dom, sec, ter := pickAccents(palette, background, cfg.MinContrast)
 
 	colorDots := ""
-	dotSource := append([]CC{}, valid...)
-	seen := make(map[string]bool)
-	for _, v := range valid {
-		seen[v.C] = true
-	}
-	for _, a := range all {
-		if !seen[a.C] && len(dotSource) < 8 {
-			dotSource = append(dotSource, a)
-			seen[a.C] = true
-		}
-	}
-	for i := 0; i < len(dotSource) && i < 8; i++ {
-		colorDots += "\x1b[38;2;" + dotSource[i].C + "m● " + reset
+	dotSource := palette[:min(len(palette), 8)]
+	for _, s := range dotSource {
+		colorDots += "\x1b[38;2;" + s.C + "m● " + reset
 	}
 
 	// 5. Assemble Rows
 	speciesVal := pokemonName
 	if isShiny {
-		speciesVal = "SHINY " + pokemonName + "!!"
+		speciesVal = trf("SHINY %s!!", pokemonName)
 	}
 
 	trainer := cfg.TrainerName
@@ -423,16 +612,30 @@ func main() {
 		trainer = os.Getenv("USER")
 	}
 
-	var rows []Row
-	rows = append(rows, Row{K: "󰦔 Trainer", V: trainer + "@cachyos"})
-	rows = append(rows, Row{K: "󰄭 Species", V: speciesVal})
+	var mainRows []Row
+	mainRows = append(mainRows, Row{K: "󰦔 " + tr("Trainer"), V: trainer + "@cachyos"})
+	mainRows = append(mainRows, Row{K: "󰄭 " + tr("Species"), V: speciesVal})
 
 	if len(types) > 0 {
-		rows = append(rows, Row{K: "󰓎 Type", V: formatTypeBadges(types, reset), IsRaw: true})
+		mainRows = append(mainRows, Row{K: "󰓎 " + tr("Type"), V: formatTypeBadges(types, reset), IsRaw: true, IsTypes: true})
+	}
+	mainRows = append(mainRows, dexRows(entry, cfg.DexRows)...)
+	evoBase, evoHL := "\x1b[38;2;"+sec+"m", "\x1b[1;4;38;2;"+dom+"m"
+	if slices.Contains(cfg.DexRows, "evolution") {
+		mainRows = append(mainRows, evolutionRow(entry.Number)...)
 	}
 
 	if stats.ShinyCount > 0 {
-		rows = append(rows, Row{K: "󰄳 Caught", V: fmt.Sprintf("%d Shiny Pokemon", stats.ShinyCount)})
+		mainRows = append(mainRows, Row{K: "󰄳 " + tr("Caught"), V: trf("%d Shiny Pokemon", stats.ShinyCount)})
+	}
+	for _, k := range cfg.DexRows {
+		switch {
+		case k == "stats":
+			mainRows = append(mainRows, statRows(entry)...)
+		case k == "matchups" && len(types) > 0:
+			mainRows = append(mainRows, Row{IsSep: true})
+			mainRows = append(mainRows, matchupRows(types)...)
+		}
 	}
 
 	hasFFInfo := false
@@ -483,28 +686,108 @@ func main() {
 	}
 
 	if hasFFInfo {
-		rows = append(rows, Row{IsSep: true})
-		rows = append(rows, ffInfoRows...)
+		mainRows = append(mainRows, Row{IsSep: true})
+		mainRows = append(mainRows, ffInfoRows...)
 	}
 
-	rows = append(rows, Row{IsSep: true})
-	rows = append(rows, Row{K: " Colors", V: colorDots, IsRaw: true})
+	mainRows = append(mainRows, Row{IsSep: true})
+	mainRows = append(mainRows, Row{K: " " + tr("Colors"), V: colorDots, IsRaw: true})
 
-	// 6. Build Box
-	maxK, maxV := 0, 0
-	for _, r := range rows {
-		if r.IsSep {
-			continue
+	// The details page, flipped to with i
+	dex := entry.Number
+	dexVal, genVal := tr("Unknown"), tr("Unknown")
+	if dex > 0 {
+		dexVal = fmt.Sprintf("#%04d", dex)
+		genVal = trf("Generation %s", romanGenerations[generationOf(dex)-1])
+	}
+	formVal := tr("Normal")
+	if entry.Form != "" {
+		formVal = formTitle(entry.Form)
+	}
+	if isShiny && entry.Form != "" {
+		formVal += ", " + tr("Shiny")
+	} else if isShiny {
+		formVal = tr("Shiny")
+	}
+	if req.Preview {
+		formVal = trf("%s (preview)", formVal)
+	}
+	var swatches []string
+	for _, c := range []string{dom, sec, ter} {
+		swatches = append(swatches, "\x1b[38;2;"+c+"m"+hexRGB(c)+reset)
+	}
+	detailRows := []Row{
+		{K: "󰄭 " + tr("Species"), V: pokemonName},
+		{K: "󰈚 " + tr("Dex"), V: dexVal},
+		{K: "󰗚 " + tr("Region"), V: genVal},
+	}
+	detailRows = append(detailRows, dexRows(entry, []string{"genus"})...)
+	if len(types) > 0 {
+		detailRows = append(detailRows, Row{K: "󰓎 " + tr("Type"), V: formatTypeBadges(types, reset), IsRaw: true, IsTypes: true})
+	}
+	if entry.Height > 0 {
+		detailRows = append(detailRows, Row{K: "󰹹 " + tr("Size"), V: fmt.Sprintf("%.1f m · %.1f kg", entry.Height, entry.Weight)})
+	}
+	detailRows = append(detailRows, dexRows(entry, []string{"abilities", "total"})...)
+	detailRows = append(detailRows, Row{K: "󰫢 " + tr("Form"), V: formVal})
+	detailRows = append(detailRows, evolutionRow(entry.Number)...)
+	detailRows = append(detailRows, statRows(entry)...)
+	if entry.Flavor != "" {
+		detailRows = append(detailRows, Row{IsSep: true})
+		for i, line := range wrapVisible(entry.Flavor, flavorWidth) {
+			if i == 0 {
+				detailRows = append(detailRows, Row{K: "󰂺 " + tr("Entry"), V: line})
+			} else {
+				detailRows = append(detailRows, Row{V: line, IsCont: true})
+			}
 		}
-		if l := getVisibleLen(r.K); l > maxK {
-			maxK = l
+	}
+	detailRows = append(detailRows,
+		Row{IsSep: true},
+		Row{K: " " + tr("Palette"), V: strings.Join(swatches, " "), IsRaw: true},
+	)
+
+	// Pages of the box, stepped through with i and the mouse wheel
+	type boxPage struct {
+		title string
+		rows  []Row
+	}
+	pages := []boxPage{{" " + tr("POKéDEX") + " ", mainRows}, {" " + tr("DETAILS") + " ", detailRows}}
+	matchupPage := -1
+	if len(types) > 0 {
+		rows := []Row{{K: "󰓎 " + tr("Type"), V: formatTypeBadges(types, reset), IsRaw: true, IsTypes: true}, {IsSep: true}}
+		pages = append(pages, boxPage{" " + tr("MATCHUPS") + " ", append(rows, matchupRows(types)...)})
+		matchupPage = len(pages) - 1
+	}
+	page, showHelp := 0, false
+
+	// 6. Build Box
+	var rows []Row
+	maxK, maxV, fullV := 0, 0, 0
+	title := ""
+
+	// measure takes the rows of the page shown, with the key help below
+	// when asked for, and how wide their keys and values run.
+	measure := func() {
+		rows, title = pages[page].rows, pages[page].title
+		if showHelp {
+			rows = append(append([]Row{}, rows...), Row{IsSep: true}, Row{K: "󰌌 " + tr("Keys"), V: tr(keyHelpText)}, Row{K: "󰍽 " + tr("Mouse"), V: tr(mouseHelpText)})
 		}
-		if l := getVisibleLen(r.V); l > maxV {
-			maxV = l
+		maxK, fullV = 0, 0
+		for _, r := range rows {
+			if r.IsSep {
+				continue
+			}
+			maxK = max(maxK, getVisibleLen(r.K))
+			fullV = max(fullV, getVisibleLen(r.V))
+			for _, l := range evolutionLines(r.Evolves, evolutionWidth, "", "") {
+				fullV = max(fullV, getVisibleLen(l))
+			}
 		}
+		maxV = fullV
 	}
+	measure()
 
-	innerW := maxK + 3 + maxV + 2
 	styleKey := cfg.BoxStyle
 	if isShiny {
 		styleKey = cfg.ShinyBoxStyle
@@ -512,57 +795,78 @@ func main() {
 	style := boxStyles[styleKey]
 	domC, secC, terC := "\x1b[1;38;2;"+dom+"m", "\x1b[1;38;2;"+sec+"m", "\x1b[1;38;2;"+ter+"m"
 
-	buildBox := func(animOffset float64, borderColors []string) []string {
-		totalW := innerW + 2
-		totalH := len(rows) + 2
-
-		getB := func(char string, row, col int) string {
-			if !isShiny {
-				return domC + char + reset
-			}
-
-			// Spatial delay: new colors emerge from corners
-			// Calculate distance to nearest corner
-			dx := math.Min(float64(col), float64(totalW-1-col))
-			dy := math.Min(float64(row), float64(totalH-1-row))
-			// Corner-centric delay (diagonal distance inward)
-			dist := math.Sqrt(dx*dx + dy*dy)
-
-			// Normalize spatial offset (a larger divisor like 300.0 makes color blocks much wider)
-			spatialDelay := dist / 300.0
-
-			// Wrap it back into the animation offset to create the spread effect
-			o := animOffset - spatialDelay
-			for o < 0.0 {
-				o += 1.0
-			}
+	// fitBox lays the rows out in a box at most limit columns wide (0 for
+	// no limit), truncating or wrapping values that do not fit.
+	boxRows := rows
+	innerW, padT, boxW, boxH := 0, 0, 0, 0
+	fitBox := func(limit int) {
+		maxV = fullV
+		if limit > 0 && maxK+maxV+7 > limit {
+			maxV = max(minValueWidth, limit-maxK-7)
+		}
+		// Evolution lines wrap at the value width the box ends up with
+		boxRows = layoutEvolutions(rows, maxV, evoBase, evoHL)
+		if maxV < fullV {
+			boxRows = fitRows(boxRows, maxV, cfg.Overflow == "wrap")
+		}
+		innerW = maxK + 3 + maxV + 2
+		padT = (innerW - getVisibleLen(title)) / 2
+		boxW, boxH = innerW+2, len(boxRows)+2
+	}
+	fitBox(cfg.MaxBoxWidth)
 
-			color := getInterpolatedRGB(borderColors, o)
+	var shinyColors []string
+	for _, cc := range dotSource {
+		shinyColors = append(shinyColors, ensureContrast(cc.C, background, cfg.MinContrast))
+	}
+	// If it's a very monochromatic sprite, add some variety or just fallback
+	if len(shinyColors) < 2 {
+		shinyColors = append(shinyColors, "255;255;255")
+	}
 
-			// Subtler breathing pulse synchronized with the spread
-			pulseBase := animOffset * 2.0 * math.Pi * float64(len(borderColors))
-			pulse := 0.9 + 0.2*math.Sin(pulseBase-spatialDelay*6.0)
+	effectCfg := cfg.NormalEffect
+	if isShiny {
+		effectCfg = cfg.ShinyEffect
+	}
+	var typeRGB []string
+	for _, t := range types {
+		if rgb, ok := typeColors[t]; ok {
+			typeRGB = append(typeRGB, ensureContrast(rgb, background, cfg.MinContrast))
+		}
+	}
+	effect := newBorderEffect(effectCfg, shinyColors, typeRGB, dom)
+	// Shiny borders always move; normal ones only when animation is on
+	animated := effect.animated() && (isShiny || cfg.Animation)
+	sparkling := isShiny && cfg.Sparkles && cfg.SparkleDensity > 0
 
-			applyPulse := func(rgb string, p float64) string {
-				parts := strings.Split(rgb, ";")
-				if len(parts) < 3 {
-					return rgb
-				}
-				r, _ := strconv.Atoi(parts[0])
-				g, _ := strconv.Atoi(parts[1])
-				b, _ := strconv.Atoi(parts[2])
-				r = int(math.Max(0, math.Min(255, float64(r)*p)))
-				g = int(math.Max(0, math.Min(255, float64(g)*p)))
-				b = int(math.Max(0, math.Min(255, float64(b)*p)))
-				return fmt.Sprintf("%d;%d;%d", r, g, b)
-			}
+	if cfg.AnimationSpeed <= 0 {
+		cfg.AnimationSpeed = 1
+	}
+	frameInterval := time.Second / time.Duration(max(1, cfg.FPS))
+	// offsetAt is how far the animation has run after elapsed time
+	offsetAt := func(elapsed time.Duration) float64 {
+		return elapsed.Seconds() / animCycle.Seconds() * cfg.AnimationSpeed
+	}
+	// cycle is how long one loop of the border effect takes
+	cycle := time.Duration(math.Round(float64(animCycle) / (cfg.AnimationSpeed * effect.Speed)))
 
-			finalColor := applyPulse(color, pulse)
-			return "\x1b[1;38;2;" + finalColor + "m" + char + reset
+	// isBorderCell reports whether the box cell at row, col is drawn by getB.
+	isBorderCell := func(row, col int) bool {
+		if row == 0 {
+			return col <= padT || col > padT+getVisibleLen(title)
+		}
+		if row == len(boxRows)+1 || boxRows[row-1].IsSep {
+			return true
 		}
+		return col == 0 || col == innerW+1
+	}
 
-		title := " POKéDEX "
-		padT := (innerW - getVisibleLen(title)) / 2
+	buildBox := func(animOffset float64) []string {
+		e := effect
+		e.w, e.h = boxW, boxH
+		getB := func(char string, row, col int) string {
+			return "\x1b[1;38;2;" + e.color(animOffset, row, col) + "m" + char + reset
+		}
 
 		var bh strings.Builder
 		bh.WriteString(getB(style.TL, 0, 0))
@@ -578,7 +882,7 @@ func main() {
 
 		var bLines []string
 		bLines = append(bLines, boxHeader)
-		for rIdx, r := range rows {
+		for rIdx, r := range boxRows {
 			rowIdx := rIdx + 1
 			if r.IsSep {
 				var sb strings.Builder
@@ -594,8 +898,14 @@ func main() {
 			leftV := getB(style.V, rowIdx, 0)
 			rightV := getB(style.V, rowIdx, innerW+1)
 
-			line := leftV + " " + reset + terC + r.K + reset + strings.Repeat(" ", maxK-getVisibleLen(r.K)) + " " + domC + "➜" + reset + " "
-			if r.IsRaw {
+			arrow := "➜"
+			if r.IsCont {
+				arrow = " "
+			}
+			line := leftV + " " + reset + terC + r.K + reset + strings.Repeat(" ", maxK-getVisibleLen(r.K)) + " " + domC + arrow + reset + " "
+			if r.Stat > 0 {
+				line += statBar(r.Stat, maxV, domC, secC, reset) + " " + rightV
+			} else if r.IsRaw {
 				line += r.V
 				curLen := getVisibleLen(line)
 				line += strings.Repeat(" ", max(0, innerW-curLen+1)) + rightV
@@ -604,7 +914,7 @@ func main() {
 			}
 			bLines = append(bLines, line)
 		}
-		lastRowIdx := len(rows) + 1
+		lastRowIdx := len(boxRows) + 1
 		var bf strings.Builder
 		bf.WriteString(getB(style.BL, lastRowIdx, 0))
 		for i := 0; i < innerW; i++ {
@@ -615,87 +925,59 @@ func main() {
 		return bLines
 	}
 
-	// 7. Interactive Render Loop
-	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
-	if err != nil {
-		// Fallback to stdout for basic display
-		shinyColors := []string{}
-		for _, cc := range dotSource {
-			shinyColors = append(shinyColors, cc.C)
-		}
-		boxLines := buildBox(0, shinyColors)
-		maxH := max(len(pokeLines), len(boxLines))
-		pTop, bTop := (maxH-len(pokeLines))/2, (maxH-len(boxLines))/2
-		for i := 0; i < maxH; i++ {
-			pStr, bStr := "", ""
-			if idx := i - pTop; idx >= 0 && idx < len(pokeLines) {
-				pStr = pokeLines[idx]
-			}
-			if idx := i - bTop; idx >= 0 && idx < len(boxLines) {
-				bStr = boxLines[idx]
-			}
-			// Manual padding because %-40s breaks with ANSI codes
-			pVisible := getVisibleLen(pStr)
-			pPad := ""
-			if pVisible < 40 {
-				pPad = strings.Repeat(" ", 40-pVisible)
-			}
-			fmt.Printf("%s%s    %s\n", pStr, pPad, bStr)
-		}
-		return
+	// The sprite only reaches compose through a renderer. Text sprites are
+	// written into the lines while inline is set, as for exports and
+	// printed output; otherwise compose leaves a pokeW×pokeH block free and
+	// the renderer draws into it.
+	var sprite SpriteRenderer = newHalfBlockSprite(pokeLines)
+	inline := true
+	pokeW, pokeH := sprite.Cells()
+
+	// Frame holds where the sprite block and the box sit within the lines
+	// of a frame.
+	type Frame struct {
+		Height           int
+		SpriteX, SpriteY int
+		BoxX, BoxY       int
+		ShowSprite       bool
 	}
-	defer tty.Close()
-
-	// Hide cursor and ensure we have enough height
-	tty.WriteString("\x1b[?25l")
 
-	pokeW := 0
-	for _, l := range pokeLines {
-		if v := getVisibleLen(l); v > pokeW {
-			pokeW = v
+	// sideBySide places the sprite left of the box, both vertically centred.
+	sideBySide := func(lPad, gap int) Frame {
+		h := max(pokeH, boxH)
+		return Frame{
+			Height:  h,
+			SpriteX: lPad, SpriteY: (h - pokeH) / 2,
+			BoxX: lPad + pokeW + gap, BoxY: (h - boxH) / 2,
+			ShowSprite: true,
 		}
 	}
 
-	// Pre-calculation for stability
-	_, termH := getTermSize()
-
-	// Pre-pad with maxH newlines and move back up to "reserve" space.
-	// This prevents "climbing" duplicates when at the bottom of the terminal.
-	boxLinesTmp := buildBox(0, []string{"0;0;0"}) 
-	maxHTmp := max(len(pokeLines), len(boxLinesTmp))
-
-	vPadTmp := 0
-	if cfg.Align == "center" {
-		vPadTmp = (termH - maxHTmp) / 8
-		if vPadTmp < 1 {
-			vPadTmp = 1
+	// stacked puts the sprite centred above the box, or leaves it out.
+	stacked := func(termW int, showSprite bool) Frame {
+		boxX := cfg.Gap
+		if cfg.Align == "center" {
+			boxX = max(0, (termW-boxW)/2)
+		}
+		if !showSprite {
+			return Frame{Height: boxH, BoxX: boxX}
+		}
+		return Frame{
+			Height:  pokeH + 1 + boxH,
+			SpriteX: max(0, boxX+(boxW-pokeW)/2),
+			BoxX:    boxX, BoxY: pokeH + 1,
+			ShowSprite: true,
 		}
-	} else {
-		vPadTmp = 2
-	}
-
-	for i := 0; i < vPadTmp+maxHTmp; i++ {
-		tty.WriteString("\n")
 	}
-	tty.WriteString(fmt.Sprintf("\x1b[%dA", vPadTmp+maxHTmp)) 
-	tty.WriteString("\x1b[s")                                 
-
-	defer tty.WriteString("\x1b[?25h")
 
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGWINCH)
+	// Live layout mode, chosen by fitSprite
+	isStacked, showSprite := false, true
 
-	var shinyColors []string
-	for _, cc := range dotSource {
-		shinyColors = append(shinyColors, cc.C)
-	}
-	// If it's a very monochromatic sprite, add some variety or just fallback
-	if len(shinyColors) < 2 {
-		shinyColors = append(shinyColors, "255;255;255")
-	}
-
-	render := func(animOffset float64) {
-		termW, termH := getTermSize()
+	// layout places the frame in a terminal termW columns wide.
+	layout := func(termW int) Frame {
+		if isStacked {
+			return stacked(termW, showSprite)
+		}
 		gap := cfg.Gap
 		lPad := 0
 		if cfg.Align == "center" {
@@ -712,46 +994,292 @@ func main() {
 			}
 			lPad = gap
 		}
-		lPadS := strings.Repeat(" ", lPad)
+		return sideBySide(lPad, gap)
+	}
 
-		boxLines := buildBox(animOffset, shinyColors)
-		maxH := max(len(pokeLines), len(boxLines))
+	// sparkleField is the area around the sprite sparkles may light up:
+	// the sprite block plus a margin, kept clear of the box.
+	sparkleField := func(f Frame) (x0, x1, y0, y1 int) {
+		if !sparkling || !f.ShowSprite || pokeW == 0 {
+			return 0, 0, 0, 0
+		}
+		x0, x1 = max(0, f.SpriteX-sparkleMargin), f.SpriteX+pokeW+sparkleMargin
+		y0, y1 = max(0, f.SpriteY-1), min(f.Height, f.SpriteY+pokeH+1)
+		if f.BoxX >= f.SpriteX+pokeW {
+			// Leave a blank column before the box
+			x1 = min(x1, max(f.SpriteX+pokeW, f.BoxX-1))
+		} else {
+			x1 = min(x1, max(f.SpriteX+pokeW, f.BoxX+boxW))
+			y1 = min(y1, f.BoxY)
+		}
+		return x0, x1, y0, y1
+	}
 
-		vPad := 0
-		if cfg.Align == "center" {
-			vPad = (termH - maxH) / 8
-			if vPad < 1 {
-				vPad = 1
+	// spriteText is the sprite's text, nil for image protocols.
+	spriteText := func() []string {
+		if ts, ok := sprite.(TextSprite); ok {
+			return ts.Lines()
+		}
+		return nil
+	}
+
+	// embedded reports whether compose writes the sprite into the lines.
+	embedded := func() bool {
+		return inline && spriteText() != nil
+	}
+
+	// compose draws the sprite and box into the lines of frame f.
+	compose := func(animOffset float64, f Frame) []string {
+		boxLines := buildBox(animOffset)
+		sx0, sx1, sy0, sy1 := sparkleField(f)
+
+		var lines []string
+		for i := 0; i < f.Height; i++ {
+			var sb strings.Builder
+			col := 0
+			idx := i - f.SpriteY
+			spriteRow := f.ShowSprite && idx >= 0 && idx < pokeH
+			if i >= sy0 && i < sy1 {
+				// Build the sparkle field cell by cell around the sprite
+				cells := make([]Cell, sx1-sx0)
+				for j := range cells {
+					cells[j].Ch = " "
+				}
+				var skip []bool
+				if spriteRow {
+					if text := spriteText(); text != nil {
+						copy(cells[f.SpriteX-sx0:], parseANSILine(text[idx]))
+					} else {
+						skip = make([]bool, len(cells))
+						for j := f.SpriteX - sx0; j < f.SpriteX-sx0+pokeW; j++ {
+							skip[j] = true
+						}
+					}
+				}
+				sparkleCells(cells, skip, animOffset, i, sx0, cfg.SparkleDensity, shinyColors)
+				sb.WriteString(strings.Repeat(" ", sx0))
+				sb.WriteString(renderCells(cells, skip))
+				col = sx1
+			} else if spriteRow {
+				sb.WriteString(strings.Repeat(" ", f.SpriteX))
+				if !embedded() {
+					// Skip over the sprite block so it is not overwritten
+					if pokeW > 0 {
+						sb.WriteString(fmt.Sprintf("\x1b[%dC", pokeW))
+					}
+				} else {
+					sb.WriteString(spriteText()[idx])
+				}
+				col = f.SpriteX + pokeW
 			}
-		} else {
-			vPad = 2
+			if !embedded() {
+				// Clear only from here on, so the sprite block survives
+				if idx := i - f.BoxY; idx >= 0 && idx < len(boxLines) {
+					sb.WriteString(strings.Repeat(" ", max(0, f.BoxX-col)))
+				}
+				sb.WriteString("\x1b[K")
+			}
+			if idx := i - f.BoxY; idx >= 0 && idx < len(boxLines) {
+				if embedded() {
+					sb.WriteString(strings.Repeat(" ", max(0, f.BoxX-col)))
+				}
+				sb.WriteString(boxLines[idx])
+			}
+			lines = append(lines, sb.String())
 		}
+		return lines
+	}
 
-		// Return to saved position
-		if !cfg.PrintAndExit {
-			tty.WriteString("\x1b[u")
-			tty.WriteString(strings.Repeat("\n", vPad))
+	if opts.Export != "" {
+		f := sideBySide(0, cfg.Gap)
+		lines := compose(0, f)
+		var anim *htmlAnimation
+		if animated && opts.Animate && effect.cyclic() {
+			e := effect
+			e.w, e.h = boxW, boxH
+			anim = &htmlAnimation{
+				Color:    func(o float64) string { return e.cycleColor(o, o, 0) },
+				Duration: cycle,
+				Delay: func(row, col int) (float64, bool) {
+					bRow, bCol := row-f.BoxY, col-f.BoxX
+					if bRow < 0 || bRow >= boxH || bCol < 0 || bCol >= boxW || !isBorderCell(bRow, bCol) {
+						return 0, false
+					}
+					return e.delay(bRow, bCol), true
+				},
+			}
+		}
+		switch opts.Export {
+		case "html":
+			fmt.Print(exportHTML(lines, anim))
+		default:
+			fmt.Fprintf(os.Stderr, "Unknown export format: %s\n", opts.Export)
+			os.Exit(1)
 		}
+		return next, false
+	}
 
-		pTop, bTop := (maxH-len(pokeLines))/2, (maxH-len(boxLines))/2
-		for i := 0; i < maxH; i++ {
-			pStr := strings.Repeat(" ", pokeW)
-			if idx := i - pTop; idx >= 0 && idx < len(pokeLines) {
-				pStr = strings.Repeat(" ", (pokeW-getVisibleLen(pokeLines[idx]))/2) + pokeLines[idx]
-				pStr += strings.Repeat(" ", pokeW-getVisibleLen(pStr))
+	if opts.Record != "" {
+		duration := opts.RecordDuration
+		if duration <= 0 {
+			duration = cycle
+		}
+		f := sideBySide(0, cfg.Gap)
+		frame := func(elapsed time.Duration) []string {
+			if !animated && !sparkling {
+				return compose(0, f)
 			}
-			bStr := ""
-			if idx := i - bTop; idx >= 0 && idx < len(boxLines) {
-				bStr = boxLines[idx]
+			return compose(offsetAt(elapsed), f)
+		}
+		width := pokeW + cfg.Gap + boxW
+		if err := recordCast(opts.Record, "shinefetch: "+speciesVal, width, f.Height, duration, frameInterval, frame); err != nil {
+			fmt.Fprintf(os.Stderr, "Error recording %s: %v\n", opts.Record, err)
+			os.Exit(1)
+		}
+		return next, false
+	}
+
+	// 7. Interactive Render Loop
+	// The tty stays open across encounters, so a reroll redraws in place
+	if !sess.live {
+		if err := sess.open(cfg); err != nil {
+			// Fallback to stdout for basic display
+			boxLines := buildBox(0)
+			maxH := max(len(pokeLines), len(boxLines))
+			pTop, bTop := (maxH-len(pokeLines))/2, (maxH-len(boxLines))/2
+			for i := 0; i < maxH; i++ {
+				pStr, bStr := "", ""
+				if idx := i - pTop; idx >= 0 && idx < len(pokeLines) {
+					pStr = pokeLines[idx]
+				}
+				if idx := i - bTop; idx >= 0 && idx < len(boxLines) {
+					bStr = boxLines[idx]
+				}
+				// Manual padding because %-40s breaks with ANSI codes
+				pVisible := getVisibleLen(pStr)
+				pPad := ""
+				if pVisible < 40 {
+					pPad = strings.Repeat(" ", 40-pVisible)
+				}
+				fmt.Printf("%s%s    %s\n", pStr, pPad, bStr)
 			}
+			return next, false
+		}
+	}
+	term, anchor, protocol := sess.term, sess.anchor, sess.protocol
+	baseW, baseH := pokeW, pokeH
+	// Only printed output keeps the sprite in its lines; the live view
+	// redraws the rest of the frame around it
+	inline = cfg.PrintAndExit
+
+	// fitLayout fits the box to the terminal width, then builds the sprite
+	// at the configured scale, shrunk so that it fits beside the box. When
+	// that would take it below minSideScale of the configured size, the
+	// sprite moves above the box instead, or is left out when the terminal
+	// is too short for both. It reports whether the layout changed.
+	spriteScale := -1.0
+	fitLayout := func() bool {
+		termW, termH := getTermSize()
+		oldW, oldH := boxW, boxH
+		limit := termW
+		if cfg.MaxBoxWidth > 0 {
+			limit = min(limit, cfg.MaxBoxWidth)
+		}
+		fitBox(limit)
+
+		budgetW := termW - boxW - 2
+		if cfg.Align != "center" {
+			budgetW -= 2
+		}
+		scale := fitSpriteScale(cfg.SpriteScale, baseW, baseH, budgetW, termH-2)
+		stack, show := scale < cfg.SpriteScale*minSideScale, true
+		if stack {
+			scale = fitSpriteScale(cfg.SpriteScale, baseW, baseH, termW, termH-2-boxH-1)
+			show = float64(baseH)*scale >= minStackedRows
+		}
+		if scale == spriteScale && stack == isStacked && show == showSprite {
+			return boxW != oldW || boxH != oldH
+		}
+		spriteScale, isStacked, showSprite = scale, stack, show
+		sprite = newSpriteRenderer(protocol, pokeLines, scale, cfg.SpriteFilter)
+		pokeW, pokeH = sprite.Cells()
+		return true
+	}
+	fitLayout()
 
-			lineOut := lPadS + pStr + strings.Repeat(" ", gap) + bStr
+	// verticalPad is the number of blank lines above a frame maxH lines tall.
+	verticalPad := func(termH, maxH int) int {
+		if sess.fullscreen {
+			return max(0, (termH-maxH)/2)
+		}
+		if cfg.Align != "center" {
+			return 2
+		}
+		return max(1, (termH-maxH)/8)
+	}
+
+	// Pre-calculation for stability
+	termW, termH := getTermSize()
+	firstH := layout(termW).Height
+	sess.reserve(verticalPad(termH, firstH) + firstH)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGWINCH)
+	defer signal.Stop(sigChan)
+
+	// Where the sprite was last drawn; it is redrawn only when it moves or
+	// the terminal was resized.
+	spriteAt, spriteDirty := [2]int{-1, -1}, true
+	// The last frame drawn, its layout and top padding, diffed against the
+	// next and hit-tested by mouse clicks
+	var shown [][]Cell
+	var shownFrame Frame
+	shownPad := 0
+
+	render := func(animOffset float64) {
+		termW, termH := getTermSize()
+		f := layout(termW)
+		lines := compose(animOffset, f)
+		maxH := len(lines)
+		vPad := verticalPad(termH, maxH)
+
+		redraw := f.ShowSprite && pokeH > 0 && !embedded() && (spriteDirty || spriteAt != [2]int{vPad + f.SpriteY, f.SpriteX})
+
+		// Unless something moved, rewrite only the cells that changed
+		if !cfg.PrintAndExit {
+			cells := frameCells(lines)
+			full := redraw || spriteDirty || shown == nil || vPad != shownPad || maxH != len(shown)
+			prev := shown
+			shown, shownFrame, shownPad = cells, f, vPad
+			if !full {
+				term.WriteString(diffFrame(prev, cells, vPad, anchor))
+				return
+			}
+		}
+
+		// The frame goes out in one write, so a signal restoring the
+		// terminal never lands in the middle of it
+		var out strings.Builder
+
+		// Return to saved position
+		if !cfg.PrintAndExit {
+			sess.reserve(vPad + maxH)
+			out.WriteString(anchor)
+			if redraw || spriteDirty {
+				// Lines skip over the sprite block, so clear the old sprite first
+				out.WriteString("\x1b[J")
+			}
+			out.WriteString(strings.Repeat("\n", vPad))
+		}
+		spriteDirty = false
+
+		for i, lineOut := range lines {
 			if !cfg.PrintAndExit {
-				// Clear line, print, and move to absolute next line WITHOUT scrolling
-				// \r = home, \x1b[2K = clear line, \x1b[1B = move down 1
-				tty.WriteString("\r\x1b[2K" + lineOut)
+				// Print over the line and move to absolute next line WITHOUT scrolling
+				// \r = home; compose clears the rest of the line itself
+				out.WriteString("\r" + lineOut)
 				if i < maxH-1 {
-					tty.WriteString("\n")
+					out.WriteString("\n")
 				}
 			} else {
 				fmt.Println(lineOut)
@@ -759,61 +1287,163 @@ func main() {
 		}
 		// Clear below just in case
 		if !cfg.PrintAndExit {
-			tty.WriteString("\x1b[J")
+			out.WriteString("\x1b[J")
+		}
+
+		if redraw {
+			spriteAt = [2]int{vPad + f.SpriteY, f.SpriteX}
+			if cfg.PrintAndExit {
+				// Image protocols: step back up to the sprite's top-left
+				// cell and return to the line below the output afterwards
+				fmt.Printf("\x1b7\x1b[%dA\x1b[%dG%s\x1b8", maxH-f.SpriteY, f.SpriteX+1, sprite.Draw())
+			} else {
+				seq := anchor
+				if vPad+f.SpriteY > 0 {
+					seq += fmt.Sprintf("\x1b[%dB", vPad+f.SpriteY)
+				}
+				seq += fmt.Sprintf("\x1b[%dG", f.SpriteX+1) + sprite.Draw() + anchor
+				if vPad+maxH-1 > 0 {
+					seq += fmt.Sprintf("\x1b[%dB", vPad+maxH-1)
+				}
+				out.WriteString(seq)
+			}
 		}
+		term.WriteString(out.String())
 	}
 
 	if cfg.PrintAndExit {
 		render(0.0)
-		return
+		return next, false
 	}
 
-	ticker := time.NewTicker(50 * time.Millisecond)
-	defer ticker.Stop()
+	// Only tick while something moves, so a still view costs nothing
+	var tick <-chan time.Time
+	if animated || sparkling {
+		ticker := time.NewTicker(frameInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	var timeout <-chan time.Time
+	if cfg.AnimationDuration > 0 {
+		timeout = time.After(time.Duration(cfg.AnimationDuration * float64(time.Second)))
+	}
 
+	start := time.Now()
 	animOffset := 0.0
 	render(animOffset)
 
-	keyChan := make(chan struct{}, 1)
-	go func() {
-		fd := int(tty.Fd())
-		var old syscall.Termios
-		// TCGETS = 0x5401, TCSETS = 0x5402
-		syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), 0x5401, uintptr(unsafe.Pointer(&old)))
-		newState := old
-		newState.Lflag &^= syscall.ECHO | syscall.ICANON
-		syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), 0x5402, uintptr(unsafe.Pointer(&newState)))
-
-		var b [1]byte
-		tty.Read(b[:])
-
-		// Restore terminal first
-		syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), 0x5402, uintptr(unsafe.Pointer(&old)))
+	// showPage switches the box to another page, or the help on and off
+	showPage := func(p int, help bool) {
+		page, showHelp = p, help
+		measure()
+		fitLayout()
+		spriteDirty = true
+		render(animOffset)
+	}
 
-		// TIOCSTI (0x5412) - Re-insert the character into the TTY input buffer
-		// so it shows up on the new line in the shell prompt.
-		if b[0] != 0 && b[0] != 27 && b[0] != 13 { // Don't re-insert null, Esc, or Enter
-			syscall.Syscall(syscall.SYS_IOCTL, uintptr(fd), 0x5412, uintptr(unsafe.Pointer(&b[0])))
+	// onSprite and onTypes report whether the screen cell x, y of the last
+	// frame shows the sprite or the type badges. The frame's screen row is
+	// unknown when the terminal did not report the cursor position.
+	frameRow := func(y int) int {
+		if sess.top < 0 {
+			return -1
 		}
-
-		keyChan <- struct{}{}
-	}()
+		return y - sess.top - shownPad
+	}
+	onSprite := func(x, y int) bool {
+		f, fy := shownFrame, frameRow(y)
+		return f.ShowSprite && fy >= f.SpriteY && fy < f.SpriteY+pokeH && x >= f.SpriteX && x < f.SpriteX+pokeW
+	}
+	onTypes := func(x, y int) bool {
+		f, fy := shownFrame, frameRow(y)
+		r := fy - f.BoxY - 1
+		if fy < 0 || r < 0 || r >= len(boxRows) || !boxRows[r].IsTypes {
+			return false
+		}
+		// Values start after the border, key and arrow
+		vx := x - (f.BoxX + maxK + 5)
+		return vx >= 0 && vx < getVisibleLen(boxRows[r].V)
+	}
 
 	for {
 		select {
 		case <-sigChan:
+			fitLayout()
+			spriteDirty = true
+			render(animOffset)
+		case <-tick:
+			animOffset = offsetAt(time.Since(start))
 			render(animOffset)
-		case <-ticker.C:
-			if isShiny || cfg.Animation {
-				animOffset += 0.0017
-				if animOffset > 1.0 {
-					animOffset -= 1.0
+		case <-timeout:
+			// Settle on a still frame and hand the terminal back
+			sparkling = false
+			render(0)
+			term.WriteString("\n")
+			sess.stopKeys()
+			return next, false
+		case key, open := <-sess.keys:
+			if !open {
+				term.WriteString("\n")
+				return next, false
+			}
+			if events, ok := parseMouse(key); ok {
+				for _, ev := range events {
+					if !ev.Press {
+						continue
+					}
+					switch {
+					case ev.Button == mouseWheelDown:
+						showPage((page+1)%len(pages), showHelp)
+					case ev.Button == mouseWheelUp:
+						showPage((page+len(pages)-1)%len(pages), showHelp)
+					case ev.Button != mouseLeft:
+					case onSprite(ev.X, ev.Y):
+						return encounterRequest{}, true
+					case onTypes(ev.X, ev.Y) && matchupPage >= 0:
+						// A second click goes back to the overview
+						if page == matchupPage {
+							showPage(0, showHelp)
+						} else {
+							showPage(matchupPage, showHelp)
+						}
+					}
 				}
-				render(animOffset)
+				continue
+			}
+			action := parseKey(key, cfg.Interactive)
+			switch action {
+			case keyQuit, keyPass:
+				term.WriteString("\n")
+				// Keys typed after the one that closed the view go to the
+				// shell as well
+				pending := sess.stopKeys()
+				term.Restore()
+				if action == keyPass {
+					pending = append(append([]byte(nil), key...), pending...)
+				}
+				sess.passKey(pending, *cfg, opts.EmitKey)
+				return next, false
+			case keyReroll:
+				return encounterRequest{}, true
+			case keyShiny:
+				if dex > 0 {
+					return encounterRequest{Dex: dex, Shiny: !isShiny, Preview: true}, true
+				}
+			case keyNext, keyPrev:
+				// Stepping from an unknown Pokemon starts at either end of the dex
+				from, step := dex, 1
+				if action == keyPrev {
+					step = -1
+					if dex == 0 {
+						from = len(NationalDex) + 1
+					}
+				}
+				return encounterRequest{Dex: dexNumber(dexName(from + step)), Shiny: isShiny, Preview: true}, true
+			case keyInfo:
+				showPage((page+1)%len(pages), showHelp)
+			case keyHelp:
+				showPage(page, !showHelp)
 			}
-		case <-keyChan:
-			tty.WriteString("\x1b[?25h\n")
-			return
 		}
 	}
 }
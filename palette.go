@@ -0,0 +1,332 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ──────────────── Palette Extraction ────────────────
+
+const (
+	paletteClusters = 8    // most colors kept from a sprite
+	mergeDistance   = 0.05 // OKLab distance below which two shades count as one
+	accentDistance  = 0.12 // OKLab distance dom, sec and ter should keep apart
+	minChroma       = 0.04 // below this a color counts as grey
+)
+
+// Swatch is one color of a sprite's palette: the most common real pixel
+// color of a cluster and how many pixels the cluster covers.
+type Swatch struct {
+	C   string // "r;g;b"
+	N   int
+	Lab [3]float64
+}
+
+func (s Swatch) chroma() float64 { return math.Hypot(s.Lab[1], s.Lab[2]) }
+
+func srgbToLinear(c float64) float64 {
+	c /= 255
+	if c <= 0.04045 {
+		return c / 12.92
+	}
+	return math.Pow((c+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(c float64) float64 {
+	if c <= 0.0031308 {
+		c *= 12.92
+	} else {
+		c = 1.055*math.Pow(c, 1/2.4) - 0.055
+	}
+	return math.Max(0, math.Min(255, math.Round(c*255)))
+}
+
+func splitRGB(rgb string) (float64, float64, float64) {
+	c := parseRGB(rgb)
+	return float64(c.R), float64(c.G), float64(c.B)
+}
+
+// hexRGB formats an "r;g;b" color as #rrggbb.
+func hexRGB(rgb string) string {
+	c := parseRGB(rgb)
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func joinRGB(r, g, b float64) string {
+	return strconv.Itoa(int(r)) + ";" + strconv.Itoa(int(g)) + ";" + strconv.Itoa(int(b))
+}
+
+// toOKLab converts an "r;g;b" color to OKLab, where euclidean distance
+// follows perceived difference.
+func toOKLab(rgb string) [3]float64 {
+	r, g, b := splitRGB(rgb)
+	lr, lg, lb := srgbToLinear(r), srgbToLinear(g), srgbToLinear(b)
+	l := math.Cbrt(0.4122214708*lr + 0.5363325363*lg + 0.0514459929*lb)
+	m := math.Cbrt(0.2119034982*lr + 0.6806995451*lg + 0.1073969566*lb)
+	s := math.Cbrt(0.0883024619*lr + 0.2817188376*lg + 0.6299787005*lb)
+	return [3]float64{
+		0.2104542553*l + 0.7936177850*m - 0.0040720468*s,
+		1.9779984951*l - 2.4285922050*m + 0.4505937099*s,
+		0.0259040371*l + 0.7827717662*m - 0.8086757660*s,
+	}
+}
+
+func fromOKLab(lab [3]float64) string {
+	l := math.Pow(lab[0]+0.3963377774*lab[1]+0.2158037573*lab[2], 3)
+	m := math.Pow(lab[0]-0.1055613458*lab[1]-0.0638541728*lab[2], 3)
+	s := math.Pow(lab[0]-0.0894841775*lab[1]-1.2914855480*lab[2], 3)
+	return joinRGB(
+		linearToSRGB(4.0767416621*l-3.3077115913*m+0.2309699292*s),
+		linearToSRGB(-1.2684380046*l+2.6097574011*m-0.3413193965*s),
+		linearToSRGB(-0.0041960863*l-0.7034186147*m+1.7076147010*s),
+	)
+}
+
+func labDist(a, b [3]float64) float64 {
+	return math.Sqrt((a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2]))
+}
+
+// extractPalette clusters the opaque pixels of img with weighted k-means in
+// OKLab, merges clusters that are near-identical shades and returns them
+// most common first.
+func extractPalette(img *image.NRGBA) []Swatch {
+	counts := make(map[string]int)
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if c := img.NRGBAAt(x, y); c.A >= 128 {
+				counts[joinRGB(float64(c.R), float64(c.G), float64(c.B))]++
+			}
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+
+	colors := make([]Swatch, 0, len(counts))
+	for c, n := range counts {
+		colors = append(colors, Swatch{C: c, N: n, Lab: toOKLab(c)})
+	}
+	// Map iteration is random; sort so clustering is deterministic
+	sort.Slice(colors, func(i, j int) bool {
+		if colors[i].N != colors[j].N {
+			return colors[i].N > colors[j].N
+		}
+		return colors[i].C < colors[j].C
+	})
+
+	// Seed with the most common color, then repeatedly with the color
+	// farthest from all seeds, weighted by how common it is.
+	k := min(paletteClusters, len(colors))
+	centers := [][3]float64{colors[0].Lab}
+	for len(centers) < k {
+		best, bestScore := -1, 0.0
+		for i, c := range colors {
+			d := math.Inf(1)
+			for _, ctr := range centers {
+				d = math.Min(d, labDist(c.Lab, ctr))
+			}
+			if score := d * d * float64(c.N); score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		if best < 0 {
+			break
+		}
+		centers = append(centers, colors[best].Lab)
+	}
+
+	assign := make([]int, len(colors))
+	for iter := 0; iter < 20; iter++ {
+		changed := false
+		for i, c := range colors {
+			nearest, nd := 0, math.Inf(1)
+			for j, ctr := range centers {
+				if d := labDist(c.Lab, ctr); d < nd {
+					nearest, nd = j, d
+				}
+			}
+			if assign[i] != nearest || iter == 0 {
+				assign[i], changed = nearest, true
+			}
+		}
+		if !changed {
+			break
+		}
+		sums := make([][4]float64, len(centers))
+		for i, c := range colors {
+			w := float64(c.N)
+			s := &sums[assign[i]]
+			s[0], s[1], s[2], s[3] = s[0]+c.Lab[0]*w, s[1]+c.Lab[1]*w, s[2]+c.Lab[2]*w, s[3]+w
+		}
+		for j, s := range sums {
+			if s[3] > 0 {
+				centers[j] = [3]float64{s[0] / s[3], s[1] / s[3], s[2] / s[3]}
+			}
+		}
+	}
+
+	// Represent each cluster by its most common real color
+	clusters := make([]Swatch, len(centers))
+	for i, c := range colors {
+		cl := &clusters[assign[i]]
+		if cl.C == "" {
+			cl.C, cl.Lab = c.C, c.Lab
+		}
+		cl.N += c.N
+	}
+
+	var palette []Swatch
+	for _, cl := range clusters {
+		if cl.N > 0 {
+			palette = append(palette, cl)
+		}
+	}
+	sort.SliceStable(palette, func(i, j int) bool { return palette[i].N > palette[j].N })
+
+	// Fold near-identical shades into the more common one
+	var merged []Swatch
+	for _, s := range palette {
+		dup := false
+		for i := range merged {
+			if labDist(merged[i].Lab, s.Lab) < mergeDistance {
+				merged[i].N += s.N
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
+
+// pickAccents chooses the dominant, secondary and tertiary UI colors from
+// a palette: the most common colorful swatches that stay clearly apart
+// from each other, each adjusted to reach contrast ratio against bg.
+func pickAccents(palette []Swatch, bg string, ratio float64) (string, string, string) {
+	fallback := []string{"32;252;0", "0;255;255", "255;0;255"}
+
+	// Colorful swatches first, greys and outlines only as a last resort
+	var candidates []Swatch
+	for _, s := range palette {
+		if s.chroma() >= minChroma && s.Lab[0] > 0.2 {
+			candidates = append(candidates, s)
+		}
+	}
+	for _, s := range palette {
+		if s.chroma() < minChroma || s.Lab[0] <= 0.2 {
+			candidates = append(candidates, s)
+		}
+	}
+
+	var picked []Swatch
+	for _, dist := range []float64{accentDistance, accentDistance / 2, 0} {
+		for _, c := range candidates {
+			if len(picked) == 3 {
+				break
+			}
+			ok := true
+			for _, p := range picked {
+				if p.C == c.C || labDist(p.Lab, c.Lab) < dist {
+					ok = false
+					break
+				}
+			}
+			if ok {
+				picked = append(picked, c)
+			}
+		}
+	}
+
+	accents := make([]string, 3)
+	for i := range accents {
+		accents[i] = fallback[i]
+		if i < len(picked) {
+			accents[i] = picked[i].C
+		}
+		accents[i] = ensureContrast(accents[i], bg, ratio)
+	}
+	return accents[0], accents[1], accents[2]
+}
+
+// relativeLuminance is the WCAG luminance of an "r;g;b" color.
+func relativeLuminance(rgb string) float64 {
+	r, g, b := splitRGB(rgb)
+	return 0.2126*srgbToLinear(r) + 0.7152*srgbToLinear(g) + 0.0722*srgbToLinear(b)
+}
+
+// contrastRatio is the WCAG contrast ratio between two colors, 1 to 21.
+func contrastRatio(a, b string) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// ensureContrast lightens (on dark backgrounds) or darkens (on light
+// ones) a color in OKLab, keeping its hue, until it reaches ratio against
+// bg or runs out of room.
+func ensureContrast(rgb, bg string, ratio float64) string {
+	if contrastRatio(rgb, bg) >= ratio {
+		return rgb
+	}
+	lab := toOKLab(rgb)
+	step := 0.02
+	if isLightBackground(bg) {
+		step = -step
+	}
+	for i := 0; i < 50; i++ {
+		lab[0] = math.Max(0, math.Min(1, lab[0]+step))
+		// Pull chroma in as lightness nears the ends so the color stays in gamut
+		edge := math.Min(lab[0], 1-lab[0])
+		if c := math.Hypot(lab[1], lab[2]); c > edge {
+			lab[1], lab[2] = lab[1]*edge/c, lab[2]*edge/c
+		}
+		out := fromOKLab(lab)
+		if contrastRatio(out, bg) >= ratio || lab[0] == 0 || lab[0] == 1 {
+			return out
+		}
+	}
+	return fromOKLab(lab)
+}
+
+// isLightBackground reports whether bg sits above the luminance where black
+// and white text contrast equally with it.
+func isLightBackground(bg string) bool { return relativeLuminance(bg) > 0.18 }
+
+// detectBackground returns the terminal background color. A "dark" or
+// "light" setting wins; otherwise tty, when there is one, is asked with
+// OSC 11, then $COLORFGBG is consulted, and a dark background is assumed
+// last.
+func detectBackground(setting string, tty *os.File) string {
+	switch setting {
+	case "dark":
+		return "0;0;0"
+	case "light":
+		return "255;255;255"
+	}
+	if tty != nil {
+		if bg, err := queryBackground(tty); err == nil {
+			return bg
+		}
+	}
+	return backgroundHint()
+}
+
+// backgroundHint guesses the terminal background from $COLORFGBG, set by
+// rxvt, Konsole and others, assuming a dark background otherwise.
+func backgroundHint() string {
+	parts := strings.Split(os.Getenv("COLORFGBG"), ";")
+	if idx, err := strconv.Atoi(parts[len(parts)-1]); err == nil && idx >= 0 && idx < 16 {
+		return basicColors[idx]
+	}
+	return "0;0;0"
+}
@@ -1024,6 +1024,7 @@ var PokemonTypes = map[string][]string{
 	"frigibax":     {"dragon", "ice"},
 	"arctibax":     {"dragon", "ice"},
 	"baxcalibur":   {"dragon", "ice"},
+	"gimmighoul":   {"ghost"},
 	"gholdengo":    {"steel", "ghost"},
 	"wo-chien":     {"dark", "grass"},
 	"chien-pao":    {"dark", "ice"},
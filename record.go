@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+)
+
+// ──────────────── Asciicast Recording ────────────────
+
+type castHeader struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// recordCast writes an asciicast v2 file by stepping the animation one
+// interval at a time for the given duration. frame returns the lines drawn
+// at an elapsed time; frames identical to the previous one are skipped.
+func recordCast(path, title string, width, height int, duration, interval time.Duration, frame func(elapsed time.Duration) []string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	err = enc.Encode(castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: time.Now().Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": "xterm-256color", "SHELL": os.Getenv("SHELL")},
+	})
+	if err != nil {
+		return err
+	}
+
+	event := func(t time.Duration, data string) error {
+		return enc.Encode([]any{t.Seconds(), "o", data})
+	}
+
+	// Hide the cursor and start from a clean screen
+	if err := event(0, "\x1b[?25l\x1b[2J\x1b[H"); err != nil {
+		return err
+	}
+
+	prev := ""
+	var t time.Duration
+	for ; t <= duration; t += interval {
+		out := "\x1b[H" + strings.Join(frame(t), "\r\n")
+		if out != prev {
+			if err := event(t, out); err != nil {
+				return err
+			}
+			prev = out
+		}
+	}
+
+	if err := event(t, "\x1b[?25h\r\n"); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ──────────────── Frame Diffing ────────────────
+
+// frameCells parses composed frame lines into rows of cells.
+func frameCells(lines []string) [][]Cell {
+	rows := make([][]Cell, len(lines))
+	for i, l := range lines {
+		rows[i] = parseANSILine(l)
+	}
+	return rows
+}
+
+// diffFrame returns the output that turns frame prev into next, where both
+// are drawn top rows below the position anchor moves the cursor to and
+// have the same number of rows. Only runs of changed cells are rewritten, each reached
+// with cursor positioning; Keep cells are never touched.
+func diffFrame(prev, next [][]Cell, top int, anchor string) string {
+	var b strings.Builder
+	moveTo := func(row, col int) {
+		b.WriteString(anchor)
+		if top+row > 0 {
+			fmt.Fprintf(&b, "\x1b[%dB", top+row)
+		}
+		fmt.Fprintf(&b, "\x1b[%dG", col+1)
+	}
+
+	for r, cells := range next {
+		old := prev[r]
+		for c := 0; c < len(cells); {
+			if cells[c].Keep || (c < len(old) && cells[c] == old[c]) {
+				c++
+				continue
+			}
+			// Start on the left half of a wide rune
+			start := c
+			if cells[start].Ch == "" && start > 0 && !cells[start-1].Keep {
+				start--
+			}
+			end := c + 1
+			for end < len(cells) && !cells[end].Keep && (end >= len(old) || cells[end] != old[end] || cells[end].Ch == "") {
+				end++
+			}
+			moveTo(r, start)
+			b.WriteString(renderCells(cells[start:end], nil))
+			c = end
+		}
+		if len(cells) < len(old) {
+			moveTo(r, len(cells))
+			b.WriteString("\x1b[K")
+		}
+	}
+	if b.Len() > 0 {
+		// Leave the cursor on the last row as a full redraw does
+		b.WriteString(anchor)
+		if top+len(next)-1 > 0 {
+			fmt.Fprintf(&b, "\x1b[%dB", top+len(next)-1)
+		}
+	}
+	return b.String()
+}
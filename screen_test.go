@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestDiffFrame(t *testing.T) {
+	gold := "\x1b[1;38;2;250;210;60m"
+	tests := []struct {
+		name       string
+		prev, next []string
+		top        int
+		want       string
+	}{
+		{"unchanged", []string{"╭──╮", "│ab│", "╰──╯"}, []string{"╭──╮", "│ab│", "╰──╯"}, 2, ""},
+		{"one border cell", []string{"╭──╮", "│ab│"}, []string{"╭──╮", "│ab┃"}, 2, "\x1b[H\x1b[3B\x1b[4G┃\x1b[H\x1b[3B"},
+		{
+			"border cell color",
+			[]string{gold + "╭─╮\x1b[0m"},
+			[]string{gold + "╭\x1b[38;2;255;0;0m─" + gold + "╮\x1b[0m"},
+			0,
+			"\x1b[H\x1b[2G\x1b[1m\x1b[38;2;255;0;0m─\x1b[0m\x1b[H",
+		},
+		{"wide rune replaced", []string{"a漢b"}, []string{"a字b"}, 0, "\x1b[H\x1b[2G字\x1b[H"},
+		{"wide rune to narrow", []string{"漢b"}, []string{"xyb"}, 0, "\x1b[H\x1b[1Gxy\x1b[H"},
+		{"narrow to wide rune", []string{"xyb"}, []string{"漢b"}, 0, "\x1b[H\x1b[1G漢\x1b[H"},
+		{"shrinking row", []string{"abcd", "ef"}, []string{"ab", "ef"}, 0, "\x1b[H\x1b[3G\x1b[K\x1b[H\x1b[1B"},
+		{"shrinking and changed", []string{"abcd"}, []string{"xb"}, 1, "\x1b[H\x1b[1B\x1b[1Gx\x1b[H\x1b[1B\x1b[3G\x1b[K\x1b[H\x1b[1B"},
+		{"keep cells untouched", []string{"abcd"}, []string{"a\x1b[2Cd"}, 0, ""},
+		{"keep cells split runs", []string{"abcd"}, []string{"x\x1b[2Cy"}, 0, "\x1b[H\x1b[1Gx\x1b[H\x1b[4Gy\x1b[H"},
+		{"growing row", []string{"ab"}, []string{"abcd"}, 0, "\x1b[H\x1b[3Gcd\x1b[H"},
+	}
+	for _, tt := range tests {
+		got := diffFrame(frameCells(tt.prev), frameCells(tt.next), tt.top, "\x1b[H")
+		if got != tt.want {
+			t.Errorf("%s: diffFrame = %q; want %q", tt.name, got, tt.want)
+		}
+	}
+}
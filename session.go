@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ──────────────── Live Session ────────────────
+
+// encounterRequest picks the Pokemon run shows next.
+type encounterRequest struct {
+	Dex     int  // National Dex number, 0 for a random encounter
+	Shiny   bool // with Dex set, show the shiny form
+	Preview bool // a preview does not count towards caught shinies
+}
+
+// options are the command-line flags.
+type options struct {
+	Export         string
+	Animate        bool
+	Record         string
+	RecordDuration time.Duration
+	EmitKey        bool
+}
+
+// readKeys reads key presses from the tty for the key reader.
+var readKeys = (*os.File).Read
+
+// session is the terminal state that outlives a single encounter, so that
+// rerolling redraws in place instead of starting over.
+type session struct {
+	tty        *os.File
+	term       *termState
+	keys       chan []byte   // key presses read from the tty, one per receive
+	stop       chan struct{} // closed by stopKeys to end the key reader
+	fullscreen bool
+	anchor     string // moves the cursor to the top-left of the frame area
+	protocol   string
+	reserved   int // lines reserved below the anchor
+	top        int // screen row of the anchor, -1 when unknown
+	background string
+	live       bool // open has set up the live view
+}
+
+// attach opens /dev/tty and hands it to a termState before anything changes
+// it, so that even the terminal queries made ahead of the live view are
+// undone on SIGINT/SIGTERM/SIGHUP.
+func (s *session) attach() error {
+	if s.tty != nil {
+		return nil
+	}
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	// Every change to the terminal goes through term, which undoes them on
+	// return, on SIGINT/SIGTERM/SIGHUP and when main panics
+	s.tty, s.term = tty, newTermState(tty)
+	s.term.catchSignals()
+	return nil
+}
+
+// open takes over /dev/tty for the live view: it hides the cursor, enters
+// the alternate screen in fullscreen mode, settles the sprite protocol and,
+// unless printing once, starts reading keys.
+func (s *session) open(cfg *Config) error {
+	if err := s.attach(); err != nil {
+		return err
+	}
+	tty := s.tty
+	s.live = true
+
+	// Hide cursor and ensure we have enough height
+	s.term.enter("\x1b[?25l", "\x1b[?25h")
+
+	// Fullscreen draws on the alternate screen, centred both ways and
+	// anchored at the top-left corner; otherwise frames are drawn below the
+	// cursor position saved by reserve.
+	s.fullscreen = cfg.Fullscreen && !cfg.PrintAndExit
+	s.anchor = "\x1b[u"
+	if s.fullscreen {
+		cfg.Align = "center"
+		s.anchor = "\x1b[H"
+		s.term.enter("\x1b[?1049h\x1b[H\x1b[2J", "\x1b[?1049l")
+	} else if top, err := cursorRow(tty); err == nil {
+		s.top = top
+	} else {
+		s.top = -1
+	}
+
+	s.protocol = cfg.SpriteProtocol
+	if s.protocol == "sixel" && !supportsSixel(tty) {
+		s.protocol = "halfblock"
+	}
+
+	if !cfg.PrintAndExit {
+		// Mouse reports are turned off with the cursor on every exit path
+		if cfg.Interactive {
+			s.term.enter(mouseOn, mouseOff)
+		}
+		s.term.setRaw()
+		s.keys, s.stop = make(chan []byte, 8), make(chan struct{})
+		go func() {
+			defer s.term.guard()
+			// keys is closed only on a clean stop: closing it while a panic
+			// unwinds would let the view end normally and exit with status 0
+			for {
+				select {
+				case <-s.stop:
+					close(s.keys)
+					return
+				default:
+				}
+				// An empty read is the raw mode timeout, not the end of input
+				buf := make([]byte, 64)
+				n, err := readKeys(tty, buf)
+				for _, key := range splitKeys(buf[:n]) {
+					s.keys <- key
+				}
+				if err != nil && !errors.Is(err, io.EOF) {
+					close(s.keys)
+					return
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+// reserve pre-pads n lines below the saved position and moves back up,
+// so the frame never scrolls the terminal while being redrawn. This
+// prevents "climbing" duplicates when at the bottom of the terminal.
+func (s *session) reserve(n int) {
+	if s.fullscreen || n <= s.reserved {
+		return
+	}
+	var seq string
+	if s.reserved > 0 {
+		seq = "\x1b[u"
+	}
+	// The padding scrolls the anchor up once it runs past the bottom
+	if s.top >= 0 {
+		_, termH := getTermSize()
+		s.top = max(0, min(s.top+n, termH-1)-n)
+	}
+	seq += strings.Repeat("\n", n) + fmt.Sprintf("\x1b[%dA", n) + "\x1b[s"
+	s.term.WriteString(seq)
+	s.reserved = n
+}
+
+// stopKeys ends the key reader so nothing else is taken from the tty input,
+// leaving later keys, and any passed back, for the shell. It returns the
+// text of the keys already read but not handled.
+func (s *session) stopKeys() []byte {
+	if s.stop == nil {
+		return nil
+	}
+	close(s.stop)
+	var pending []byte
+	for key := range s.keys {
+		pending = append(pending, passableKey(key)...)
+	}
+	s.stop = nil
+	return pending
+}
+
+// passKey hands the keys that ended the view on to the shell: printed on
+// stdout for a shell widget with --emit-key, else pushed back into the tty
+// input with TIOCSTI where the kernel still allows it.
+func (s *session) passKey(key []byte, cfg Config, emitKey bool) {
+	switch {
+	case len(key) == 0 || cfg.KeyPassthrough == "none":
+	case emitKey:
+		os.Stdout.Write(key)
+	default:
+		// The shell must read the key with echo back on
+		s.term.restoreTermios()
+		if err := injectInput(s.tty, key); err != nil {
+			fmt.Fprintf(os.Stderr, "shinefetch: could not pass the key back to the shell (TIOCSTI: %v).\n"+
+				"Use shell integration (shinefetch --emit-key), set \"key_passthrough\": \"none\" or \"animation_duration\" to silence this.\n", err)
+		}
+	}
+}
+
+// close restores the terminal and releases the tty.
+func (s *session) close() {
+	if s.tty == nil {
+		return
+	}
+	s.term.Restore()
+	s.tty.Close()
+}
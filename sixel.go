@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"sort"
+	"strings"
+)
+
+// ──────────────── Sixel Graphics ────────────────
+
+const sixelMaxColors = 255
+
+// quantize reduces the opaque colors of img to at most n entries with a
+// median cut and returns the palette and each pixel's palette index (-1
+// for transparent pixels).
+func quantize(img *image.NRGBA, n int) ([]color.NRGBA, []int) {
+	b := img.Bounds()
+	counts := make(map[color.NRGBA]int)
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if c := img.NRGBAAt(x, y); c.A >= 128 {
+				c.A = 255
+				counts[c]++
+			}
+		}
+	}
+
+	colors := make([]color.NRGBA, 0, len(counts))
+	for c := range counts {
+		colors = append(colors, c)
+	}
+
+	// Split the box with the widest channel range at its median until
+	// there are enough boxes, then average each box.
+	boxes := [][]color.NRGBA{colors}
+	for len(boxes) < n {
+		best, bestRange, bestCh := -1, 0, 0
+		for i, box := range boxes {
+			if len(box) < 2 {
+				continue
+			}
+			for ch := 0; ch < 3; ch++ {
+				lo, hi := 255, 0
+				for _, c := range box {
+					v := int([]uint8{c.R, c.G, c.B}[ch])
+					lo, hi = min(lo, v), max(hi, v)
+				}
+				if hi-lo > bestRange {
+					best, bestRange, bestCh = i, hi-lo, ch
+				}
+			}
+		}
+		if best < 0 {
+			break
+		}
+		box := boxes[best]
+		sort.Slice(box, func(i, j int) bool {
+			return []uint8{box[i].R, box[i].G, box[i].B}[bestCh] < []uint8{box[j].R, box[j].G, box[j].B}[bestCh]
+		})
+		mid := len(box) / 2
+		boxes[best] = box[:mid]
+		boxes = append(boxes, box[mid:])
+	}
+
+	palette := make([]color.NRGBA, 0, len(boxes))
+	lookup := make(map[color.NRGBA]int)
+	for _, box := range boxes {
+		if len(box) == 0 {
+			continue
+		}
+		var r, g, bl, w int
+		for _, c := range box {
+			k := counts[c]
+			r, g, bl, w = r+int(c.R)*k, g+int(c.G)*k, bl+int(c.B)*k, w+k
+		}
+		for _, c := range box {
+			lookup[c] = len(palette)
+		}
+		palette = append(palette, color.NRGBA{uint8(r / w), uint8(g / w), uint8(bl / w), 255})
+	}
+
+	idx := make([]int, b.Dx()*b.Dy())
+	for y := 0; y < b.Dy(); y++ {
+		for x := 0; x < b.Dx(); x++ {
+			c := img.NRGBAAt(b.Min.X+x, b.Min.Y+y)
+			if c.A < 128 {
+				idx[y*b.Dx()+x] = -1
+				continue
+			}
+			c.A = 255
+			idx[y*b.Dx()+x] = lookup[c]
+		}
+	}
+	return palette, idx
+}
+
+// scaleNearest enlarges img by an integer factor without smoothing.
+func scaleNearest(img *image.NRGBA, k int) *image.NRGBA {
+	b := img.Bounds()
+	out := image.NewNRGBA(image.Rect(0, 0, b.Dx()*k, b.Dy()*k))
+	for y := 0; y < b.Dy()*k; y++ {
+		for x := 0; x < b.Dx()*k; x++ {
+			out.SetNRGBA(x, y, img.NRGBAAt(b.Min.X+x/k, b.Min.Y+y/k))
+		}
+	}
+	return out
+}
+
+// encodeSixel renders img as a Sixel DCS sequence. Transparent pixels are
+// left untouched so the terminal background shows through.
+func encodeSixel(img *image.NRGBA) string {
+	palette, idx := quantize(img, sixelMaxColors)
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+
+	var b strings.Builder
+	// P2=1: pixels that are not drawn keep their current color
+	fmt.Fprintf(&b, "\x1bP0;1;0q\"1;1;%d;%d", w, h)
+	for i, c := range palette {
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", i, int(c.R)*100/255, int(c.G)*100/255, int(c.B)*100/255)
+	}
+
+	writeRun := func(ch byte, n int) {
+		switch {
+		case n >= 4:
+			fmt.Fprintf(&b, "!%d%c", n, ch)
+		default:
+			b.WriteString(strings.Repeat(string(ch), n))
+		}
+	}
+
+	row := make([]byte, w)
+	for band := 0; band < h; band += 6 {
+		used := make(map[int]bool)
+		for y := band; y < min(band+6, h); y++ {
+			for x := 0; x < w; x++ {
+				if i := idx[y*w+x]; i >= 0 {
+					used[i] = true
+				}
+			}
+		}
+		order := make([]int, 0, len(used))
+		for i := range used {
+			order = append(order, i)
+		}
+		sort.Ints(order)
+
+		for n, ci := range order {
+			for x := 0; x < w; x++ {
+				bits := 0
+				for dy := 0; dy < 6 && band+dy < h; dy++ {
+					if idx[(band+dy)*w+x] == ci {
+						bits |= 1 << dy
+					}
+				}
+				row[x] = byte(63 + bits)
+			}
+
+			fmt.Fprintf(&b, "#%d", ci)
+			end := w
+			for end > 0 && row[end-1] == 63 {
+				end--
+			}
+			for x := 0; x < end; {
+				j := x
+				for j < end && row[j] == row[x] {
+					j++
+				}
+				writeRun(row[x], j-x)
+				x = j
+			}
+			if n < len(order)-1 {
+				b.WriteByte('$')
+			}
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return b.String()
+}
@@ -0,0 +1,42 @@
+package main
+
+import "math"
+
+// ──────────────── Shiny Sparkles ────────────────
+
+// sparkleGlyphs is the life of one sparkle, from appearing to fading out.
+var sparkleGlyphs = []string{"·", "✧", "✦", "✧", "·"}
+
+const (
+	sparkleMargin = 2    // columns around the sprite sparkles may use
+	sparkleLife   = 0.04 // share of an animation cycle one sparkle lasts
+)
+
+// sparkleAt returns the glyph and color of the sparkle lit at a cell at
+// animOffset, if any. density is the share of cells lit at a time.
+func sparkleAt(animOffset float64, row, col int, density float64, colors []string) (string, string, bool) {
+	// Stagger cells so that sparkles do not all start together
+	t := animOffset/sparkleLife + float64(cellHash(row, col, -1)%1000)/1000
+	slot := math.Floor(t)
+	h := cellHash(row, col, int(slot))
+	if float64(h%10000)/10000 >= density || len(colors) == 0 {
+		return "", "", false
+	}
+	p := t - slot
+	glyph := sparkleGlyphs[min(int(p*float64(len(sparkleGlyphs))), len(sparkleGlyphs)-1)]
+	color := scaleRGB(colors[int(h/10000)%len(colors)], 0.6+0.6*math.Sin(p*math.Pi))
+	return glyph, color, true
+}
+
+// sparkleCells scatters sparkles over the empty cells of a row segment
+// starting at column x0 of frame row row. Cells in skip are left alone.
+func sparkleCells(cells []Cell, skip []bool, animOffset float64, row, x0 int, density float64, colors []string) {
+	for i, c := range cells {
+		if (skip != nil && skip[i]) || c.Ch != " " || c.BG != "" {
+			continue
+		}
+		if glyph, color, ok := sparkleAt(animOffset, row, x0+i, density, colors); ok {
+			cells[i] = Cell{Ch: glyph, FG: color, Bold: true}
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ──────────────── Sprite Pixels ────────────────
+
+func parseRGB(s string) color.NRGBA {
+	parts := strings.Split(s, ";")
+	if len(parts) < 3 {
+		return color.NRGBA{}
+	}
+	r, _ := strconv.Atoi(parts[0])
+	g, _ := strconv.Atoi(parts[1])
+	b, _ := strconv.Atoi(parts[2])
+	return color.NRGBA{uint8(r), uint8(g), uint8(b), 255}
+}
+
+// decodeSprite rebuilds the sprite's pixels from pokeget's half-block
+// output, two pixels per cell. Cells without a color stay transparent.
+func decodeSprite(lines []string) *image.NRGBA {
+	w := 0
+	rows := make([][]Cell, len(lines))
+	for i, l := range lines {
+		rows[i] = parseANSILine(l)
+		w = max(w, len(rows[i]))
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, len(lines)*2))
+	set := func(x, y int, rgb string) {
+		if rgb != "" {
+			img.SetNRGBA(x, y, parseRGB(rgb))
+		}
+	}
+	for y, cells := range rows {
+		for x, c := range cells {
+			switch c.Ch {
+			case "▀":
+				set(x, y*2, c.FG)
+				set(x, y*2+1, c.BG)
+			case "▄":
+				set(x, y*2, c.BG)
+				set(x, y*2+1, c.FG)
+			case "█":
+				set(x, y*2, c.FG)
+				set(x, y*2+1, c.FG)
+			case " ":
+				set(x, y*2, c.BG)
+				set(x, y*2+1, c.BG)
+			}
+		}
+	}
+	return img
+}
+
+// resizeSprite scales img to w×h pixels. The "nearest" filter keeps hard
+// pixel edges; "area" averages every source pixel a target pixel covers,
+// weighted by coverage, which keeps detail when shrinking.
+func resizeSprite(img *image.NRGBA, w, h int, filter string) *image.NRGBA {
+	b := img.Bounds()
+	sw, sh := b.Dx(), b.Dy()
+	out := image.NewNRGBA(image.Rect(0, 0, w, h))
+	fx, fy := float64(sw)/float64(w), float64(sh)/float64(h)
+
+	if filter == "nearest" {
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				sx := min(sw-1, int((float64(x)+0.5)*fx))
+				sy := min(sh-1, int((float64(y)+0.5)*fy))
+				out.SetNRGBA(x, y, img.NRGBAAt(b.Min.X+sx, b.Min.Y+sy))
+			}
+		}
+		return out
+	}
+
+	for y := 0; y < h; y++ {
+		y0, y1 := float64(y)*fy, float64(y+1)*fy
+		for x := 0; x < w; x++ {
+			x0, x1 := float64(x)*fx, float64(x+1)*fx
+			var r, g, bl, a, area float64
+			for sy := int(y0); sy < sh && float64(sy) < y1; sy++ {
+				cy := math.Min(y1, float64(sy+1)) - math.Max(y0, float64(sy))
+				for sx := int(x0); sx < sw && float64(sx) < x1; sx++ {
+					cov := cy * (math.Min(x1, float64(sx+1)) - math.Max(x0, float64(sx)))
+					c := img.NRGBAAt(b.Min.X+sx, b.Min.Y+sy)
+					// Premultiply so transparent pixels do not darken edges
+					ca := float64(c.A) / 255 * cov
+					r, g, bl, a = r+float64(c.R)*ca, g+float64(c.G)*ca, bl+float64(c.B)*ca, a+ca
+					area += cov
+				}
+			}
+			if a > 0 {
+				out.SetNRGBA(x, y, color.NRGBA{uint8(r / a), uint8(g / a), uint8(bl / a), uint8(math.Round(a / area * 255))})
+			}
+		}
+	}
+	return out
+}
+
+// encodeHalfBlocks renders img as half-block lines, two pixels per cell,
+// the inverse of decodeSprite. Mostly transparent pixels are left out.
+func encodeHalfBlocks(img *image.NRGBA) []string {
+	b := img.Bounds()
+	opaque := func(x, y int) (string, bool) {
+		if y >= b.Dy() {
+			return "", false
+		}
+		c := img.NRGBAAt(b.Min.X+x, b.Min.Y+y)
+		return fmt.Sprintf("%d;%d;%d", c.R, c.G, c.B), c.A >= 128
+	}
+
+	var lines []string
+	for y := 0; y < b.Dy(); y += 2 {
+		var sb strings.Builder
+		for x := 0; x < b.Dx(); x++ {
+			top, hasTop := opaque(x, y)
+			bot, hasBot := opaque(x, y+1)
+			switch {
+			case hasTop && hasBot:
+				sb.WriteString("\x1b[38;2;" + top + "m\x1b[48;2;" + bot + "m▀\x1b[0m")
+			case hasTop:
+				sb.WriteString("\x1b[38;2;" + top + "m▀\x1b[0m")
+			case hasBot:
+				sb.WriteString("\x1b[38;2;" + bot + "m▄\x1b[0m")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		lines = append(lines, sb.String())
+	}
+	return lines
+}
+
+// spriteCells returns the integer pixel scale that draws each sprite pixel
+// as a square half a cell tall, matching the footprint of the half-block
+// rendering, and how many cells the w×h sprite covers at that scale.
+func spriteCells(w, h int) (scale, cols, rows int) {
+	cellW, cellH := getCellSize()
+	if cellW == 0 || cellH == 0 {
+		cellW, cellH = 8, 16
+	}
+	scale = max(1, cellH/2)
+	return scale, (w*scale + cellW - 1) / cellW, (h*scale + cellH - 1) / cellH
+}
+
+// ──────────────── Sprite Renderers ────────────────
+
+// SpriteRenderer draws the sprite into a block of terminal cells. The
+// layout only needs the block's size; Draw is called with the cursor on
+// the block's top-left cell whenever the block moves or the terminal is
+// resized, and may leave the cursor anywhere.
+type SpriteRenderer interface {
+	Cells() (cols, rows int)
+	Draw() string
+}
+
+// TextSprite is a SpriteRenderer whose sprite is plain text. Its lines can
+// be written into a frame directly, so sparkles light up around it cell by
+// cell and printed output keeps the sprite when redirected.
+type TextSprite interface {
+	SpriteRenderer
+	Lines() []string // rows of the block, each padded to its full width
+}
+
+// newSpriteRenderer picks the renderer for a sprite protocol, falling back
+// to half-blocks for unknown protocols or an empty sprite. factor resizes
+// the sprite with the given filter before it is drawn.
+func newSpriteRenderer(protocol string, lines []string, factor float64, filter string) SpriteRenderer {
+	if len(lines) == 0 || (protocol == "halfblock" && factor == 1) {
+		return newHalfBlockSprite(lines)
+	}
+	img := decodeSprite(lines)
+	if factor != 1 {
+		w := max(1, int(math.Round(float64(img.Bounds().Dx())*factor)))
+		h := max(1, int(math.Round(float64(img.Bounds().Dy())*factor)))
+		img = resizeSprite(img, w, h, filter)
+	}
+	scale, cols, rows := spriteCells(img.Bounds().Dx(), img.Bounds().Dy())
+	switch protocol {
+	case "kitty":
+		return &kittySprite{img: img, cols: cols, rows: rows}
+	case "sixel":
+		return &imageSprite{seq: encodeSixel(scaleNearest(img, scale)), cols: cols, rows: rows}
+	case "iterm2":
+		return &imageSprite{seq: encodeITerm2(scaleNearest(img, scale), cols, rows), cols: cols, rows: rows}
+	}
+	return newHalfBlockSprite(encodeHalfBlocks(img))
+}
+
+// fitSpriteScale shrinks the requested scale until a sprite cols×rows
+// cells large at scale 1 fits in the given budget.
+func fitSpriteScale(scale float64, cols, rows, budgetW, budgetH int) float64 {
+	if cols == 0 || rows == 0 {
+		return scale
+	}
+	if float64(cols)*scale > float64(budgetW) {
+		scale = float64(budgetW) / float64(cols)
+	}
+	if float64(rows)*scale > float64(budgetH) {
+		scale = float64(budgetH) / float64(rows)
+	}
+	// Never shrink below a single cell
+	return math.Max(scale, 1/float64(min(cols, rows)))
+}
+
+type halfBlockSprite struct {
+	lines []string
+	cols  int
+}
+
+func newHalfBlockSprite(lines []string) *halfBlockSprite {
+	s := &halfBlockSprite{lines: lines}
+	for _, l := range lines {
+		s.cols = max(s.cols, getVisibleLen(l))
+	}
+	return s
+}
+
+func (s *halfBlockSprite) Cells() (int, int) { return s.cols, len(s.lines) }
+
+// Lines centres each row of the sprite in the block.
+func (s *halfBlockSprite) Lines() []string {
+	rows := make([]string, len(s.lines))
+	for i, l := range s.lines {
+		w := getVisibleLen(l)
+		pad := (s.cols - w) / 2
+		rows[i] = strings.Repeat(" ", pad) + l + strings.Repeat(" ", s.cols-w-pad)
+	}
+	return rows
+}
+
+func (s *halfBlockSprite) Draw() string {
+	var b strings.Builder
+	lines := s.Lines()
+	for i, l := range lines {
+		b.WriteString(l)
+		// Back to the block's left edge on the next row
+		b.WriteString(fmt.Sprintf("\x1b[%dD", s.cols))
+		if i < len(lines)-1 {
+			b.WriteString("\x1b[1B")
+		}
+	}
+	return b.String()
+}
+
+// kittySprite uploads the image on the first draw and only re-places it
+// afterwards.
+type kittySprite struct {
+	img        image.Image
+	cols, rows int
+	sent       bool
+}
+
+func (s *kittySprite) Cells() (int, int) { return s.cols, s.rows }
+
+func (s *kittySprite) Draw() string {
+	seq := kittyPlace(kittyImageID, s.cols, s.rows)
+	if !s.sent {
+		s.sent = true
+		seq = kittyTransmit(s.img, kittyImageID) + seq
+	}
+	return seq
+}
+
+// imageSprite replays a pre-encoded image sequence.
+type imageSprite struct {
+	seq        string
+	cols, rows int
+}
+
+func (s *imageSprite) Cells() (int, int) { return s.cols, s.rows }
+
+func (s *imageSprite) Draw() string { return s.seq }
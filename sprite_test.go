@@ -0,0 +1,26 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHalfBlockSpriteLines(t *testing.T) {
+	tests := []struct {
+		lines, want []string
+	}{
+		{nil, []string{}},
+		{[]string{"▀▀"}, []string{"▀▀"}},
+		{[]string{"▄", "████", "▀▀▀"}, []string{" ▄  ", "████", "▀▀▀ "}},
+		{[]string{"\x1b[38;2;1;2;3m▄\x1b[0m", "▀▀▀"}, []string{" \x1b[38;2;1;2;3m▄\x1b[0m ", "▀▀▀"}},
+	}
+	for _, tt := range tests {
+		s := newHalfBlockSprite(tt.lines)
+		if got := s.Lines(); !slices.Equal(got, tt.want) {
+			t.Errorf("Lines() of %q = %q; want %q", tt.lines, got, tt.want)
+		}
+		if cols, rows := s.Cells(); rows != len(tt.want) || (rows > 0 && cols != getVisibleLen(tt.want[0])) {
+			t.Errorf("Cells() of %q = %d, %d", tt.lines, cols, rows)
+		}
+	}
+}
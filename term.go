@@ -0,0 +1,285 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// ──────────────── Terminal Queries ────────────────
+
+// TCGETS = 0x5401, TCSETS = 0x5402
+const (
+	ioctlTCGETS = 0x5401
+	ioctlTCSETS = 0x5402
+)
+
+func getTermios(fd uintptr) (syscall.Termios, error) {
+	var t syscall.Termios
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlTCGETS, uintptr(unsafe.Pointer(&t))); errno != 0 {
+		return t, errno
+	}
+	return t, nil
+}
+
+func setTermios(fd uintptr, t *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlTCSETS, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+var errQueryTimeout = errors.New("terminal did not answer")
+
+// queryTerminal writes query to the tty and collects the reply until done
+// reports it complete or the timeout expires. Echo and line buffering are
+// turned off while waiting so the reply never shows up on screen.
+func queryTerminal(tty *os.File, query string, done func(reply string) bool, timeout time.Duration) (string, error) {
+	fd := tty.Fd()
+	old, err := getTermios(fd)
+	if err != nil {
+		return "", err
+	}
+	raw := old
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON
+	raw.Cc[syscall.VMIN] = 0
+	raw.Cc[syscall.VTIME] = 1 // read returns after 100ms without input
+	if err := setTermios(fd, &raw); err != nil {
+		return "", err
+	}
+	defer setTermios(fd, &old)
+
+	if _, err := tty.WriteString(query); err != nil {
+		return "", err
+	}
+
+	// The deadline covers ttys read through the poller, VTIME blocking ones
+	deadline := time.Now().Add(timeout)
+	tty.SetReadDeadline(deadline)
+	defer tty.SetReadDeadline(time.Time{})
+
+	var reply strings.Builder
+	buf := make([]byte, 64)
+	for time.Now().Before(deadline) {
+		n, err := tty.Read(buf)
+		if n > 0 {
+			reply.Write(buf[:n])
+			if done(reply.String()) {
+				return reply.String(), nil
+			}
+		}
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, os.ErrDeadlineExceeded) {
+			return reply.String(), err
+		}
+	}
+	return reply.String(), errQueryTimeout
+}
+
+// supportsSixel asks the terminal for its primary device attributes (DA1);
+// terminals that can draw Sixel graphics list attribute 4 in the reply.
+func supportsSixel(tty *os.File) bool {
+	reply, err := queryTerminal(tty, "\x1b[c", func(r string) bool {
+		i := strings.Index(r, "\x1b[?")
+		return i >= 0 && strings.Contains(r[i:], "c")
+	}, 500*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	i := strings.Index(reply, "\x1b[?")
+	attrs := reply[i+3:]
+	attrs = attrs[:strings.Index(attrs, "c")]
+	for _, a := range strings.Split(attrs, ";") {
+		if a == "4" {
+			return true
+		}
+	}
+	return false
+}
+
+// queryBackground asks the terminal for its background color with OSC 11
+// and returns it as "r;g;b". A DA1 query follows so that terminals which
+// ignore OSC 11 still answer and the wait ends early. Color replies carry
+// 1 to 4 hex digits per channel and end in either BEL or ST.
+func queryBackground(tty *os.File) (string, error) {
+	reply, err := queryTerminal(tty, "\x1b]11;?\x1b\\\x1b[c", func(r string) bool {
+		i := strings.Index(r, "\x1b[?")
+		return i >= 0 && strings.Contains(r[i:], "c")
+	}, 500*time.Millisecond)
+	i := strings.Index(reply, "\x1b]11;rgb:")
+	if i < 0 {
+		if err == nil {
+			err = errors.New("terminal did not report its background")
+		}
+		return "", err
+	}
+	spec := reply[i+len("\x1b]11;rgb:"):]
+	if end := strings.IndexAny(spec, "\a\x1b"); end >= 0 {
+		spec = spec[:end]
+	}
+	parts := strings.Split(spec, "/")
+	if len(parts) != 3 {
+		return "", errors.New("malformed background reply")
+	}
+	var rgb []string
+	for _, p := range parts {
+		v, err := strconv.ParseUint(p, 16, 16)
+		if err != nil || len(p) > 4 {
+			return "", errors.New("malformed background reply")
+		}
+		// Scale 1-4 hex digits to 0-255
+		full := uint64(1)<<(4*len(p)) - 1
+		rgb = append(rgb, strconv.FormatUint((v*255+full/2)/full, 10))
+	}
+	return strings.Join(rgb, ";"), nil
+}
+
+// cursorRow asks the terminal where the cursor is (DSR 6) and returns its
+// 0-based row.
+func cursorRow(tty *os.File) (int, error) {
+	reply, err := queryTerminal(tty, "\x1b[6n", func(r string) bool {
+		i := strings.Index(r, "\x1b[")
+		return i >= 0 && strings.Contains(r[i:], "R")
+	}, 500*time.Millisecond)
+	if err != nil {
+		return 0, err
+	}
+	i := strings.Index(reply, "\x1b[")
+	pos := reply[i+2 : i+strings.Index(reply[i:], "R")]
+	row, _, ok := strings.Cut(pos, ";")
+	n, err := strconv.Atoi(row)
+	if !ok || err != nil {
+		return 0, errors.New("malformed cursor position reply")
+	}
+	return n - 1, nil
+}
+
+// ──────────────── Terminal State ────────────────
+
+// termState remembers what shinefetch changed on the tty (line discipline,
+// cursor visibility, alternate screen) so that every way out puts it back:
+// a normal return, SIGINT, SIGTERM, SIGHUP and panics.
+type termState struct {
+	mu     sync.Mutex
+	tty    *os.File
+	saved  *syscall.Termios
+	leave  []string // sequences undoing each enter, in the order written
+	closed bool
+}
+
+// newTermState records the tty's current termios as the state to return to.
+func newTermState(tty *os.File) *termState {
+	t := &termState{tty: tty}
+	if old, err := getTermios(tty.Fd()); err == nil {
+		t.saved = &old
+	}
+	return t
+}
+
+// enter writes a sequence to the tty and remembers the one that undoes it.
+func (t *termState) enter(seq, undo string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tty.WriteString(seq)
+	t.leave = append(t.leave, undo)
+}
+
+// WriteString writes to the tty unless the terminal was already restored,
+// holding the same lock as Restore so output never interleaves with it.
+func (t *termState) WriteString(s string) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return 0, nil
+	}
+	return t.tty.WriteString(s)
+}
+
+// setRaw turns off echo and line buffering so single keys can be read.
+// Reads return after 100ms without input, so a reader can be stopped.
+func (t *termState) setRaw() {
+	if t.saved == nil {
+		return
+	}
+	raw := *t.saved
+	raw.Lflag &^= syscall.ECHO | syscall.ICANON
+	raw.Cc[syscall.VMIN] = 0
+	raw.Cc[syscall.VTIME] = 1
+	setTermios(t.tty.Fd(), &raw)
+}
+
+// restoreTermios puts the line discipline back without touching the screen.
+func (t *termState) restoreTermios() {
+	if t.saved != nil {
+		setTermios(t.tty.Fd(), t.saved)
+	}
+}
+
+// Restore undoes every change, most recent first. It is safe to call more
+// than once and from any goroutine.
+func (t *termState) Restore() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return
+	}
+	t.closed = true
+	for i := len(t.leave) - 1; i >= 0; i-- {
+		t.tty.WriteString(t.leave[i])
+	}
+	t.restoreTermios()
+}
+
+// catchSignals restores the terminal and exits when shinefetch is
+// interrupted, terminated or loses its terminal.
+func (t *termState) catchSignals() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		s := <-sig
+		t.Restore()
+		t.tty.WriteString("\r\n")
+		os.Exit(128 + int(s.(syscall.Signal)))
+	}()
+}
+
+// guard restores the terminal before a panic in a goroutine other than
+// main brings the program down. Use it as defer t.guard().
+func (t *termState) guard() {
+	if r := recover(); r != nil {
+		t.Restore()
+		panic(r)
+	}
+}
+
+// ──────────────── Key Passthrough ────────────────
+
+// ioctlTIOCSTI pushes a byte into the tty's input queue. Linux 6.2+ refuses
+// it unless dev.tty.legacy_tiocsti is set.
+const ioctlTIOCSTI = 0x5412
+
+// passableKey returns the part of a key press worth handing to the shell:
+// printable text, but not Enter, Esc or escape sequences such as arrows.
+func passableKey(key []byte) []byte {
+	if len(key) == 0 || key[0] < 0x20 || key[0] == 0x7f {
+		return nil
+	}
+	return key
+}
+
+// injectInput pushes data into the tty's input queue so that the shell
+// reads it as if typed.
+func injectInput(tty *os.File, data []byte) error {
+	for i := range data {
+		if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, tty.Fd(), ioctlTIOCSTI, uintptr(unsafe.Pointer(&data[i]))); errno != 0 {
+			return errno
+		}
+	}
+	return nil
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// TestMain lets the live view tests run shinefetch itself: the test binary
+// re-executes with SHINEFETCH_TEST_MAIN set on a pseudo-terminal.
+func TestMain(m *testing.M) {
+	switch os.Getenv("SHINEFETCH_TEST_MAIN") {
+	case "":
+		os.Exit(m.Run())
+	case "panic":
+		readKeys = func(f *os.File, b []byte) (int, error) {
+			n, err := f.Read(b)
+			if n > 0 {
+				panic("key reader")
+			}
+			return n, err
+		}
+	}
+	os.Args = os.Args[:1]
+	main()
+	os.Exit(0)
+}
+
+const (
+	ioctlTIOCGPTN   = 0x80045430
+	ioctlTIOCSPTLCK = 0x40045431
+)
+
+// openPty returns the master and slave ends of a new pseudo-terminal.
+func openPty(t *testing.T) (master, slave *os.File) {
+	t.Helper()
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminals: %v", err)
+	}
+	var unlock int32
+	var n uint32
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), ioctlTIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); errno != 0 {
+		master.Close()
+		t.Skipf("TIOCSPTLCK: %v", errno)
+	}
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, master.Fd(), ioctlTIOCGPTN, uintptr(unsafe.Pointer(&n))); errno != 0 {
+		master.Close()
+		t.Skipf("TIOCGPTN: %v", errno)
+	}
+	slave, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		t.Skipf("open slave: %v", err)
+	}
+	return master, slave
+}
+
+// startLiveView runs shinefetch in fullscreen interactive mode on a new
+// pseudo-terminal, with a stand-in pokeget and the given background
+// setting, and waits until echo is off and ready has been written.
+func startLiveView(t *testing.T, mode, background, ready string) (cmd *exec.Cmd, master, slave *os.File, output func() string) {
+	t.Helper()
+	home := t.TempDir()
+	bin := filepath.Join(home, "bin")
+	conf := filepath.Join(home, ".config", "shinefetch")
+	for _, dir := range []string{bin, conf} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The stand-in logs its arguments, one call per line
+	pokeget := "#!/bin/sh\necho \"$*\" >> \"$HOME/pokeget.log\"\nprintf 'pikachu\\n\\033[38;2;250;210;60m▄▄▄▄\\033[0m\\n\\033[38;2;250;210;60m████\\033[0m\\n'\n"
+	if err := os.WriteFile(filepath.Join(bin, "pokeget"), []byte(pokeget), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	settings := `{"fullscreen": true, "interactive": true, "background": "` + background + `", "shiny_chance": 1000000}`
+	if err := os.WriteFile(filepath.Join(conf, "settings.jsonc"), []byte(settings), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	master, slave = openPty(t)
+	cmd = exec.Command(os.Args[0], "-test.run=^$")
+	cmd.Env = append(os.Environ(), "SHINEFETCH_TEST_MAIN="+mode, "HOME="+home, "PATH="+bin+":/usr/bin:/bin", "TERM=xterm-256color")
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true, Ctty: 0}
+
+	var mu sync.Mutex
+	var out bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, 4096)
+		for {
+			n, err := master.Read(buf)
+			mu.Lock()
+			out.Write(buf[:n])
+			mu.Unlock()
+			if err != nil {
+				return
+			}
+		}
+	}()
+	output = func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return out.String()
+	}
+	t.Cleanup(func() {
+		master.Close()
+		slave.Close()
+		<-done
+	})
+
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cmd.Process.Kill() })
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		tio, err := getTermios(slave.Fd())
+		if err == nil && tio.Lflag&syscall.ECHO == 0 && strings.Contains(output(), ready) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("live view did not start; output: %q", output())
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if ready == mouseOn {
+		// Let the first frame go out
+		time.Sleep(200 * time.Millisecond)
+	}
+	return cmd, master, slave, output
+}
+
+func TestLiveViewRestoresTerminal(t *testing.T) {
+	leave := []string{"\x1b[?25h", "\x1b[?1049l", mouseOff}
+	tests := []struct {
+		name       string
+		mode       string
+		background string // "auto" queries the terminal, which never answers
+		ready      string // written once the terminal is in the state to test
+		signal     syscall.Signal
+		code       int
+		leave      []string
+	}{
+		{"SIGINT", "signal", "dark", mouseOn, syscall.SIGINT, 128 + int(syscall.SIGINT), leave},
+		{"SIGTERM", "signal", "dark", mouseOn, syscall.SIGTERM, 128 + int(syscall.SIGTERM), leave},
+		{"SIGHUP", "signal", "dark", mouseOn, syscall.SIGHUP, 128 + int(syscall.SIGHUP), leave},
+		{"key reader panic", "panic", "dark", mouseOn, 0, 2, leave},
+		{"SIGINT during background query", "signal", "auto", "\x1b]11;?", syscall.SIGINT, 128 + int(syscall.SIGINT), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, master, slave, output := startLiveView(t, tt.mode, tt.background, tt.ready)
+			if tt.signal != 0 {
+				cmd.Process.Signal(tt.signal)
+			} else {
+				master.WriteString("x")
+			}
+
+			exited := make(chan error, 1)
+			go func() { exited <- cmd.Wait() }()
+			select {
+			case <-exited:
+			case <-time.After(10 * time.Second):
+				t.Fatalf("shinefetch did not exit; output: %q", output())
+			}
+			if code := cmd.ProcessState.ExitCode(); code != tt.code {
+				t.Errorf("exit code = %d; want %d", code, tt.code)
+			}
+
+			tio, err := getTermios(slave.Fd())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tio.Lflag&syscall.ECHO == 0 || tio.Lflag&syscall.ICANON == 0 {
+				t.Errorf("echo and line buffering not restored: lflag %#x", tio.Lflag)
+			}
+
+			// Give the reader a moment to collect the last writes
+			time.Sleep(100 * time.Millisecond)
+			out := output()
+			tail := out[strings.LastIndex(out, tt.ready):]
+			for _, seq := range tt.leave {
+				if !strings.Contains(tail, seq) {
+					t.Errorf("%q not written on exit; output ends %q", seq, tail[max(0, len(tail)-200):])
+				}
+			}
+		})
+	}
+}
+
+func TestLiveViewHandlesQueuedKeys(t *testing.T) {
+	cmd, master, _, output := startLiveView(t, "keys", "dark", mouseOn)
+	// Keys pressed while the view is busy are read together
+	master.WriteString("nnnq")
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+	select {
+	case err := <-exited:
+		if err != nil {
+			t.Fatalf("shinefetch: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatalf("shinefetch did not exit; output ends %q", output()[max(0, len(output())-200):])
+	}
+
+	var home string
+	for _, e := range cmd.Env {
+		if h, ok := strings.CutPrefix(e, "HOME="); ok {
+			home = h
+		}
+	}
+	log, err := os.ReadFile(filepath.Join(home, "pokeget.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	calls := strings.Split(strings.TrimSpace(string(log)), "\n")
+	// The stand-in always shows Pikachu, so every n asks for #26
+	want := []string{"random", "26", "26", "26"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("pokeget called with %q; want %q", calls, want)
+	}
+}
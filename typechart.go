@@ -0,0 +1,64 @@
+package main
+
+// ──────────────── Type Chart ────────────────
+
+// typeOrder lists the 18 types in the games' order.
+var typeOrder = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// typeChart holds how effective an attacking type is against each
+// defending type, from Generation VI on. Pairings left out are neutral.
+var typeChart = map[string]map[string]float64{
+	"normal":   {"rock": 0.5, "ghost": 0, "steel": 0.5},
+	"fire":     {"fire": 0.5, "water": 0.5, "grass": 2, "ice": 2, "bug": 2, "rock": 0.5, "dragon": 0.5, "steel": 2},
+	"water":    {"fire": 2, "water": 0.5, "grass": 0.5, "ground": 2, "rock": 2, "dragon": 0.5},
+	"electric": {"water": 2, "electric": 0.5, "grass": 0.5, "ground": 0, "flying": 2, "dragon": 0.5},
+	"grass":    {"fire": 0.5, "water": 2, "grass": 0.5, "poison": 0.5, "ground": 2, "flying": 0.5, "bug": 0.5, "rock": 2, "dragon": 0.5, "steel": 0.5},
+	"ice":      {"fire": 0.5, "water": 0.5, "grass": 2, "ice": 0.5, "ground": 2, "flying": 2, "dragon": 2, "steel": 0.5},
+	"fighting": {"normal": 2, "ice": 2, "poison": 0.5, "flying": 0.5, "psychic": 0.5, "bug": 0.5, "rock": 2, "ghost": 0, "dark": 2, "steel": 2, "fairy": 0.5},
+	"poison":   {"grass": 2, "poison": 0.5, "ground": 0.5, "rock": 0.5, "ghost": 0.5, "steel": 0, "fairy": 2},
+	"ground":   {"fire": 2, "electric": 2, "grass": 0.5, "poison": 2, "flying": 0, "bug": 0.5, "rock": 2, "steel": 2},
+	"flying":   {"electric": 0.5, "grass": 2, "fighting": 2, "bug": 2, "rock": 0.5, "steel": 0.5},
+	"psychic":  {"fighting": 2, "poison": 2, "psychic": 0.5, "dark": 0, "steel": 0.5},
+	"bug":      {"fire": 0.5, "grass": 2, "fighting": 0.5, "poison": 0.5, "flying": 0.5, "psychic": 2, "ghost": 0.5, "dark": 2, "steel": 0.5, "fairy": 0.5},
+	"rock":     {"fire": 2, "ice": 2, "fighting": 0.5, "ground": 0.5, "flying": 2, "bug": 2, "steel": 0.5},
+	"ghost":    {"normal": 0, "psychic": 2, "ghost": 2, "dark": 0.5},
+	"dragon":   {"dragon": 2, "steel": 0.5, "fairy": 0},
+	"dark":     {"fighting": 0.5, "psychic": 2, "ghost": 2, "dark": 0.5, "fairy": 0.5},
+	"steel":    {"fire": 0.5, "water": 0.5, "electric": 0.5, "ice": 2, "rock": 2, "steel": 0.5, "fairy": 2},
+	"fairy":    {"fire": 0.5, "fighting": 2, "poison": 0.5, "dragon": 2, "dark": 2, "steel": 0.5},
+}
+
+// effectiveness is the damage multiplier of an attacking type against a
+// Pokemon of the given types.
+func effectiveness(attack string, types []string) float64 {
+	m := 1.0
+	for _, t := range types {
+		if f, ok := typeChart[attack][t]; ok {
+			m *= f
+		}
+	}
+	return m
+}
+
+// multiplierLabels names the multipliers a Pokemon of one or two types
+// can take, strongest first.
+var multiplierLabels = []struct {
+	M     float64
+	Label string
+}{{4, "4×"}, {2, "2×"}, {0.5, "½×"}, {0.25, "¼×"}, {0, "0×"}}
+
+// matchups groups the attacking types, in typeOrder, by the multiplier
+// they deal to a Pokemon of the given types. Neutral types are left out.
+func matchups(types []string) map[float64][]string {
+	groups := make(map[float64][]string)
+	for _, a := range typeOrder {
+		if m := effectiveness(a, types); m != 1 {
+			groups[m] = append(groups[m], a)
+		}
+	}
+	return groups
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// ──────────────── Value Fitting ────────────────
+
+const minValueWidth = 8 // narrowest value column a box is shrunk to
+
+// truncateVisible cuts s to at most w visible columns, ending it with "…"
+// when anything was cut. Escape sequences are kept so colors survive.
+func truncateVisible(s string, w int) string {
+	if getVisibleLen(s) <= w {
+		return s
+	}
+	var b strings.Builder
+	width, hasEsc := 0, false
+	for i := 0; i < len(s); {
+		if loc := ansiPrefix(s[i:]); loc > 0 {
+			b.WriteString(s[i : i+loc])
+			i += loc
+			hasEsc = true
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		rw := runewidth.RuneWidth(r)
+		if width+rw > w-1 {
+			break
+		}
+		b.WriteRune(r)
+		width += rw
+		i += size
+	}
+	b.WriteString("…")
+	if hasEsc {
+		b.WriteString("\x1b[0m")
+	}
+	return b.String()
+}
+
+// ansiPrefix returns the length of the CSI sequence at the start of s, or 0.
+func ansiPrefix(s string) int {
+	if len(s) < 2 || s[0] != 0x1b || s[1] != '[' {
+		return 0
+	}
+	for j := 2; j < len(s); j++ {
+		if s[j] >= 0x40 && s[j] <= 0x7e {
+			return j + 1
+		}
+	}
+	return 0
+}
+
+// wrapVisible soft-wraps plain text into lines at most w columns wide,
+// breaking at spaces and splitting words that are longer than a line.
+func wrapVisible(s string, w int) []string {
+	var lines []string
+	cur := ""
+	for _, word := range strings.Fields(s) {
+		for runewidth.StringWidth(word) > w {
+			if cur != "" {
+				lines = append(lines, cur)
+				cur = ""
+			}
+			head := runewidth.Truncate(word, w, "")
+			lines = append(lines, head)
+			word = word[len(head):]
+		}
+		switch {
+		case cur == "":
+			cur = word
+		case runewidth.StringWidth(cur)+1+runewidth.StringWidth(word) <= w:
+			cur += " " + word
+		default:
+			lines = append(lines, cur)
+			cur = word
+		}
+	}
+	if cur != "" || len(lines) == 0 {
+		lines = append(lines, cur)
+	}
+	return lines
+}
+
+// fitRows shortens every value wider than w columns. Plain values are
+// wrapped onto continuation rows when wrap is set; raw (pre-colored)
+// values and everything else are truncated.
+func fitRows(rows []Row, w int, wrap bool) []Row {
+	var out []Row
+	for _, r := range rows {
+		if r.IsSep || getVisibleLen(r.V) <= w {
+			out = append(out, r)
+			continue
+		}
+		if !wrap || r.IsRaw {
+			r.V = truncateVisible(r.V, w)
+			out = append(out, r)
+			continue
+		}
+		for i, part := range wrapVisible(r.V, w) {
+			if i == 0 {
+				out = append(out, Row{K: r.K, V: part})
+			} else {
+				out = append(out, Row{V: part, IsCont: true})
+			}
+		}
+	}
+	return out
+}